@@ -1,4 +1,18 @@
 provider "statuscake" {
   # you can also provide the api key via the STATUSCAKE_API_KEY env variable
   api_key = "..."
+
+  # tags added to every uptime test, which don't need to be repeated in the
+  # tags of each test
+  default_tags {
+    tags = ["managed-by:terraform"]
+  }
+
+  # used by every uptime test that doesn't set these itself
+  uptime_test_defaults {
+    check_rate     = 60
+    confirmation   = 3
+    regions        = ["london", "dublin"]
+    contact_groups = ["12345"]
+  }
 }
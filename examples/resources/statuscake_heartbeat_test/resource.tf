@@ -0,0 +1,12 @@
+resource "statuscake_heartbeat_test" "nightly_backup" {
+  name   = "Nightly Backup"
+  period = 90000
+  tags   = ["env:production", "app:example"]
+}
+
+# the check_url can then be passed to whatever needs to ping it, such as a
+# kubernetes cron job
+output "nightly_backup_check_url" {
+  value     = statuscake_heartbeat_test.nightly_backup.check_url
+  sensitive = true
+}
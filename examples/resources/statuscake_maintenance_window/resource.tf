@@ -0,0 +1,15 @@
+resource "statuscake_maintenance_window" "deploy_night" {
+  name            = "Deploy Night"
+  start_at        = "2030-01-01T22:00:00+13:00"
+  end_at          = "2030-01-01T23:30:00+13:00"
+  timezone        = "Pacific/Auckland"
+  repeat_interval = 7
+
+  # pause every uptime test with these tags
+  tags = ["env:production"]
+
+  # as well as these specific uptime tests
+  tests = [
+    statuscake_uptime_test.my_site.id
+  ]
+}
@@ -0,0 +1,9 @@
+resource "statuscake_pagespeed_test" "my_site" {
+  name        = "My Site"
+  website_url = "https://www.example.com"
+  check_rate  = 60
+  region      = "UK"
+
+  # alert if the page takes longer than 5 seconds to load
+  alert_slower = 5000
+}
@@ -0,0 +1,8 @@
+resource "statuscake_ssl_test" "my_site" {
+  website_url = "https://www.example.com"
+  check_rate  = 3600
+  alert_at    = [1, 7, 30]
+
+  alert_expiry = true
+  alert_broken = true
+}
@@ -4,4 +4,12 @@ resource "statuscake_uptime_test" "my_site" {
   test_type   = "HTTP"
   check_rate  = 300
   tags        = ["env:production", "app:example"]
+
+  custom_headers = {
+    "Accept-Language" = "en-GB"
+  }
+
+  sensitive_custom_headers = {
+    "Authorization" = "Bearer ${var.health_check_token}"
+  }
 }
@@ -10,13 +10,16 @@ import (
 //go:generate terraform fmt -recursive ./examples/
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
 
+// version is set by goreleaser when building a release
+var version = "dev"
+
 func main() {
 	var debugMode bool
 
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{ProviderFunc: statuscake.New("")}
+	opts := &plugin.ServeOpts{ProviderFunc: statuscake.New(version)}
 
 	plugin.Serve(opts)
 }
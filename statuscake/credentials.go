@@ -0,0 +1,188 @@
+package statuscake
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	defaultCredentialsFile = "~/.statuscake/credentials"
+	defaultProfile         = "default"
+)
+
+// resolveAPIKey finds the api key to use, in order of precedence from:
+//
+//  1. the api_key argument, or the STATUSCAKE_API_KEY environment variable
+//  2. the output of api_key_command
+//  3. the profile in the credentials file
+//
+// it returns an empty key if none of them provide one
+func resolveAPIKey(ctx context.Context, d *schema.ResourceData) (string, error) {
+	if apiKey := d.Get("api_key").(string); apiKey != "" {
+		return apiKey, nil
+	}
+
+	if command := d.Get("api_key_command").(string); command != "" {
+		return runAPIKeyCommand(ctx, command)
+	}
+
+	profile := d.Get("profile").(string)
+	explicitProfile := profile != ""
+
+	if !explicitProfile {
+		profile = defaultProfile
+	}
+
+	apiKey, err := loadCredentialsFile(d.Get("credentials_file").(string), profile)
+
+	// only the default profile is allowed to not exist, as otherwise it's most
+	// likely a typo in the profile or file name
+	if errors.Is(err, errProfileNotFound) && !explicitProfile {
+		return "", nil
+	}
+
+	return apiKey, err
+}
+
+// runAPIKeyCommand runs the command with the shell and returns what it writes to
+// stdout as the api key
+func runAPIKeyCommand(ctx context.Context, command string) (string, error) {
+	var cmd *exec.Cmd
+
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("api_key_command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	apiKey := strings.TrimSpace(stdout.String())
+
+	if apiKey == "" {
+		return "", errors.New("api_key_command didn't output an api key")
+	}
+
+	return apiKey, nil
+}
+
+var errProfileNotFound = errors.New("profile not found")
+
+// loadCredentialsFile returns the api_key of the profile in the credentials file,
+// which is an INI file with a section for each profile:
+//
+//	[default]
+//	api_key = ...
+//
+//	[staging]
+//	api_key = ...
+func loadCredentialsFile(path, profile string) (string, error) {
+	path, err := expandHomeDir(path)
+
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.Open(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%w: credentials file %s does not exist", errProfileNotFound, path)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	defer f.Close()
+
+	profiles, err := parseCredentials(f)
+
+	if err != nil {
+		return "", fmt.Errorf("could not read credentials file %s: %w", path, err)
+	}
+
+	values, ok := profiles[profile]
+
+	if !ok {
+		return "", fmt.Errorf("%w: credentials file %s has no %q profile", errProfileNotFound, path, profile)
+	}
+
+	if values["api_key"] == "" {
+		return "", fmt.Errorf("the %q profile in credentials file %s has no api_key", profile, path)
+	}
+
+	return values["api_key"], nil
+}
+
+// parseCredentials parses an INI file into the keys & values of each section
+func parseCredentials(r io.Reader) (map[string]map[string]string, error) {
+	profiles := make(map[string]map[string]string)
+	scanner := bufio.NewScanner(r)
+
+	var section map[string]string
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+
+			if _, ok := profiles[name]; !ok {
+				profiles[name] = make(map[string]string)
+			}
+
+			section = profiles[name]
+
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("line %d: expected a [profile] or key = value", n)
+		}
+
+		if section == nil {
+			return nil, fmt.Errorf("line %d: %s is not in a [profile]", n, strings.TrimSpace(parts[0]))
+		}
+
+		section[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return profiles, scanner.Err()
+}
+
+func expandHomeDir(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
@@ -0,0 +1,162 @@
+package statuscake
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const testCredentialsFile = `
+# shared StatusCake credentials
+[default]
+api_key = default-key
+
+[staging]
+api_key = staging-key
+
+; a profile without a key
+[empty]
+`
+
+func writeTestCredentialsFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "credentials")
+
+	if err := ioutil.WriteFile(path, []byte(testCredentialsFile), 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return path
+}
+
+func TestParseCredentials(t *testing.T) {
+	t.Parallel()
+
+	profiles, err := parseCredentials(strings.NewReader(testCredentialsFile))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := profiles["staging"]["api_key"]; got != "staging-key" {
+		t.Errorf("expected staging-key, got %q", got)
+	}
+	if _, ok := profiles["empty"]; !ok {
+		t.Error("expected the empty profile to be parsed")
+	}
+
+	if _, err := parseCredentials(strings.NewReader("api_key = nope")); err == nil {
+		t.Error("expected an error for a key outside of a profile")
+	}
+	if _, err := parseCredentials(strings.NewReader("[default]\nnope")); err == nil {
+		t.Error("expected an error for a line that isn't a key = value")
+	}
+}
+
+func TestResolveAPIKey(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands are written for sh")
+	}
+
+	path := writeTestCredentialsFile(t)
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	tests := []struct {
+		name    string
+		raw     map[string]interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "api_key takes precedence",
+			raw:  map[string]interface{}{"api_key": "hcl-key", "api_key_command": "echo command-key", "credentials_file": path},
+			want: "hcl-key",
+		},
+		{
+			name: "api_key_command takes precedence over the credentials file",
+			raw:  map[string]interface{}{"api_key_command": "echo '  command-key  '", "credentials_file": path},
+			want: "command-key",
+		},
+		{
+			name:    "failing api_key_command",
+			raw:     map[string]interface{}{"api_key_command": "echo oops >&2; exit 1", "credentials_file": path},
+			wantErr: true,
+		},
+		{
+			name:    "api_key_command without output",
+			raw:     map[string]interface{}{"api_key_command": "true", "credentials_file": path},
+			wantErr: true,
+		},
+		{
+			name: "default profile",
+			raw:  map[string]interface{}{"credentials_file": path},
+			want: "default-key",
+		},
+		{
+			name: "named profile",
+			raw:  map[string]interface{}{"credentials_file": path, "profile": "staging"},
+			want: "staging-key",
+		},
+		{
+			name:    "unknown profile",
+			raw:     map[string]interface{}{"credentials_file": path, "profile": "production"},
+			wantErr: true,
+		},
+		{
+			name:    "profile without a key",
+			raw:     map[string]interface{}{"credentials_file": path, "profile": "empty"},
+			wantErr: true,
+		},
+		{
+			name: "missing credentials file",
+			raw:  map[string]interface{}{"credentials_file": missing},
+			want: "",
+		},
+		{
+			name:    "missing credentials file with a profile",
+			raw:     map[string]interface{}{"credentials_file": missing, "profile": "staging"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, New("dev")().Schema, tt.raw)
+
+			// the environment variables would otherwise leak into the test
+			for _, key := range []string{"api_key", "profile"} {
+				if _, ok := tt.raw[key]; !ok {
+					_ = d.Set(key, "")
+				}
+			}
+
+			got, err := resolveAPIKey(context.Background(), d)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %q", got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
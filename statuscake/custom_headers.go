@@ -0,0 +1,378 @@
+package statuscake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"regexp"
+	"sort"
+)
+
+// reservedCustomHeaders are set by StatusCake when it makes the request, as they
+// describe the connection or the body rather than what is being requested
+var reservedCustomHeaders = []string{ //nolint:gochecknoglobals
+	"Connection",
+	"Content-Length",
+	"Host",
+	"Keep-Alive",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// headerNameRegexp matches the characters allowed in the name of a header
+var headerNameRegexp = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$") //nolint:gochecknoglobals
+
+func validateCustomHeaders(v interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	seen := make(map[string]string)
+
+	for _, name := range sortedKeys(v.(map[string]interface{})) {
+		canonical := http.CanonicalHeaderKey(name)
+
+		switch {
+		case !headerNameRegexp.MatchString(name):
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Invalid header name",
+				Detail:        fmt.Sprintf("%q is not a valid HTTP header name", name),
+				AttributePath: path,
+			})
+		case isReservedCustomHeader(canonical):
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Reserved header name",
+				Detail:        fmt.Sprintf("The %s header is set by StatusCake and can't be overridden", canonical),
+				AttributePath: path,
+			})
+		case seen[canonical] != "":
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Duplicate header name",
+				Detail:        fmt.Sprintf("%q and %q are the same header, as header names are case insensitive", seen[canonical], name),
+				AttributePath: path,
+			})
+		}
+
+		seen[canonical] = name
+	}
+
+	return diags
+}
+
+func isReservedCustomHeader(canonical string) bool {
+	for _, reserved := range reservedCustomHeaders {
+		if canonical == reserved {
+			return true
+		}
+	}
+
+	return false
+}
+
+func customizeDiffCustomHeaders(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("custom_headers") || !d.NewValueKnown("sensitive_custom_headers") {
+		return nil
+	}
+
+	_, err := mergeCustomHeaders(
+		d.Get("custom_headers").(map[string]interface{}),
+		d.Get("sensitive_custom_headers").(map[string]interface{}),
+	)
+
+	return err
+}
+
+// mergeCustomHeaders combines the custom headers with the sensitive ones, which
+// can't set the same header
+func mergeCustomHeaders(headers, sensitive map[string]interface{}) (map[string]string, error) {
+	merged := make(map[string]string, len(headers)+len(sensitive))
+	names := make(map[string]string, len(headers))
+
+	for name, value := range headers {
+		merged[name] = value.(string)
+		names[http.CanonicalHeaderKey(name)] = name
+	}
+
+	for _, name := range sortedKeys(sensitive) {
+		if other, ok := names[http.CanonicalHeaderKey(name)]; ok {
+			return nil, fmt.Errorf("the %q header is set by both custom_headers and sensitive_custom_headers (as %q)", other, name)
+		}
+
+		merged[name] = sensitive[name].(string)
+	}
+
+	return merged, nil
+}
+
+// expandCustomHeaders returns the custom headers as the JSON object expected by
+// the api, or an empty string when there aren't any
+func expandCustomHeaders(d *schema.ResourceData) (string, error) {
+	headers, err := mergeCustomHeaders(
+		d.Get("custom_headers").(map[string]interface{}),
+		d.Get("sensitive_custom_headers").(map[string]interface{}),
+	)
+
+	if err != nil || len(headers) == 0 {
+		return "", err
+	}
+
+	// maps are marshalled with sorted keys, so the same headers are always sent
+	// the same way
+	raw, err := json.Marshal(headers)
+
+	return string(raw), err
+}
+
+// parseCustomHeaders parses the JSON object of headers returned by the api
+func parseCustomHeaders(raw *string) (map[string]string, error) {
+	headers := make(map[string]string)
+
+	if raw == nil || *raw == "" {
+		return headers, nil
+	}
+
+	var values map[string]interface{}
+
+	if err := json.Unmarshal([]byte(*raw), &values); err != nil {
+		return nil, fmt.Errorf("custom headers aren't a JSON object: %w", err)
+	}
+
+	for name, value := range values {
+		if s, ok := value.(string); ok {
+			headers[name] = s
+		} else {
+			headers[name] = fmt.Sprint(value)
+		}
+	}
+
+	return headers, nil
+}
+
+// setCustomHeaders splits the headers returned by the api between the custom
+// headers and the sensitive ones, with a header being sensitive if it already is
+// in the state. Imported headers are never sensitive
+func setCustomHeaders(d *schema.ResourceData, headers map[string]string) error {
+	current := d.Get("sensitive_custom_headers").(map[string]interface{})
+	plain := make(map[string]string)
+	sensitive := make(map[string]string)
+
+	for name, value := range headers {
+		if _, ok := current[name]; ok {
+			sensitive[name] = value
+		} else {
+			plain[name] = value
+		}
+	}
+
+	if err := d.Set("custom_headers", plain); err != nil {
+		return err
+	}
+
+	return d.Set("sensitive_custom_headers", sensitive)
+}
+
+// uptimeTestSchemaV0 is the schema of an uptime test before custom_header, a
+// string holding a JSON object, was replaced by the custom_headers map. It only
+// decodes states of version 0, so it mustn't change along with the current
+// schema
+func uptimeTestSchemaV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"test_type": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"website_url": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"check_rate": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"basic_user": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"basic_pass": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"confirmation": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"contact_groups": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+				Computed: true,
+			},
+			"custom_header": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"do_not_find": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"dns_ip_csv": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"dns_server": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"enable_ssl_alert": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"final_endpoint": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"find_string": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"follow_redirects": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"host": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"include_header": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"paused": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"port": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"post_body": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"post_raw": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"regions": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+				Computed: true,
+			},
+			"status_codes": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			"tags": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			"tags_all": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed: true,
+			},
+			"timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"trigger_rate": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"cookie_storage": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"user_agent": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		},
+		Timeouts: defaultResourceTimeouts(),
+	}
+}
+
+// upgradeUptimeTestStateV0 moves the JSON object of custom_header into the
+// sensitive_custom_headers map, as there's no knowing which of the headers carry
+// tokens, and moving a header from custom_headers to sensitive_custom_headers
+// would show its old value in the plan. A value that isn't a JSON object is
+// dropped, as the next refresh reads the headers from the api anyway
+func upgradeUptimeTestStateV0(ctx context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	headers := make(map[string]interface{})
+
+	if raw, ok := rawState["custom_header"].(string); ok {
+		parsed, err := parseCustomHeaders(&raw)
+
+		if err != nil {
+			tflog.Warn(ctx, "Dropping custom_header from the state", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		for name, value := range parsed {
+			headers[name] = value
+		}
+	}
+
+	delete(rawState, "custom_header")
+
+	rawState["custom_headers"] = map[string]interface{}{}
+	rawState["sensitive_custom_headers"] = headers
+
+	return rawState, nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,195 @@
+package statuscake
+
+import (
+	"context"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"reflect"
+	"testing"
+)
+
+func TestValidateCustomHeaders(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		headers     map[string]interface{}
+		wantSummary string
+	}{
+		"valid":         {map[string]interface{}{"Authorization": "Bearer abc", "X-Request-Id": "123"}, ""},
+		"empty":         {map[string]interface{}{}, ""},
+		"host":          {map[string]interface{}{"Host": "example.com"}, "Reserved header name"},
+		"lowercase":     {map[string]interface{}{"content-length": "10"}, "Reserved header name"},
+		"invalid name":  {map[string]interface{}{"X Token": "abc"}, "Invalid header name"},
+		"colon in name": {map[string]interface{}{"X-Token:": "abc"}, "Invalid header name"},
+		"duplicate":     {map[string]interface{}{"X-Token": "abc", "x-token": "def"}, "Duplicate header name"},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := validateCustomHeaders(tt.headers, cty.GetAttrPath("custom_headers"))
+
+			if tt.wantSummary == "" {
+				if len(diags) != 0 {
+					t.Errorf("unexpected diagnostics: %v", diags)
+				}
+
+				return
+			}
+
+			if len(diags) != 1 || diags[0].Summary != tt.wantSummary {
+				t.Errorf("expected %q, got %v", tt.wantSummary, diags)
+			}
+		})
+	}
+}
+
+func TestExpandCustomHeaders(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		raw     map[string]interface{}
+		want    string
+		wantErr bool
+	}{
+		"none": {map[string]interface{}{}, "", false},
+		"sorted": {
+			map[string]interface{}{
+				"custom_headers":           map[string]interface{}{"X-B": "2", "X-A": "1"},
+				"sensitive_custom_headers": map[string]interface{}{"Authorization": "Bearer abc"},
+			},
+			`{"Authorization":"Bearer abc","X-A":"1","X-B":"2"}`,
+			false,
+		},
+		"set twice": {
+			map[string]interface{}{
+				"custom_headers":           map[string]interface{}{"Authorization": "Bearer abc"},
+				"sensitive_custom_headers": map[string]interface{}{"authorization": "Bearer def"},
+			},
+			"",
+			true,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, ResourceStatusCakeUptimeTest().Schema, tt.raw)
+
+			got, err := expandCustomHeaders(d)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error to be %t, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSetCustomHeaders(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, ResourceStatusCakeUptimeTest().Schema, map[string]interface{}{
+		"sensitive_custom_headers": map[string]interface{}{"Authorization": "Bearer old"},
+	})
+
+	raw := `{"Authorization":"Bearer new","X-Request-Id":"123","X-Retries":3}`
+
+	headers, err := parseCustomHeaders(&raw)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := setCustomHeaders(d, headers); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	wantHeaders := map[string]interface{}{"X-Request-Id": "123", "X-Retries": "3"}
+	wantSensitive := map[string]interface{}{"Authorization": "Bearer new"}
+
+	if got := d.Get("custom_headers"); !reflect.DeepEqual(got, wantHeaders) {
+		t.Errorf("expected custom_headers to be %v, got %v", wantHeaders, got)
+	}
+	if got := d.Get("sensitive_custom_headers"); !reflect.DeepEqual(got, wantSensitive) {
+		t.Errorf("expected sensitive_custom_headers to be %v, got %v", wantSensitive, got)
+	}
+
+	invalid := "X-Token: abc"
+
+	if _, err := parseCustomHeaders(&invalid); err == nil {
+		t.Error("expected an error for headers that aren't a JSON object")
+	}
+}
+
+func TestUpgradeUptimeTestStateV0(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		customHeader interface{}
+		want         map[string]interface{}
+	}{
+		"headers":  {`{"Authorization":"Bearer abc", "X-Retries": 3}`, map[string]interface{}{"Authorization": "Bearer abc", "X-Retries": "3"}},
+		"empty":    {"", map[string]interface{}{}},
+		"unset":    {nil, map[string]interface{}{}},
+		"not json": {"X-Token: abc", map[string]interface{}{}},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			state := map[string]interface{}{"id": "123", "name": "example"}
+
+			if tt.customHeader != nil {
+				state["custom_header"] = tt.customHeader
+			}
+
+			got, err := upgradeUptimeTestStateV0(context.Background(), state, nil)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			want := map[string]interface{}{
+				"id":                       "123",
+				"name":                     "example",
+				"custom_headers":           map[string]interface{}{},
+				"sensitive_custom_headers": tt.want,
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("expected %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestUptimeTestSchemaV0(t *testing.T) {
+	t.Parallel()
+
+	r := ResourceStatusCakeUptimeTest()
+
+	if err := r.InternalValidate(nil, true); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	v0 := uptimeTestSchemaV0().CoreConfigSchema()
+
+	if a, ok := v0.Attributes["custom_header"]; !ok || !a.Type.Equals(cty.String) {
+		t.Error("expected version 0 to have custom_header as a string")
+	}
+	if _, ok := v0.Attributes["custom_headers"]; ok {
+		t.Error("expected version 0 not to have custom_headers")
+	}
+}
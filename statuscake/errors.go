@@ -0,0 +1,151 @@
+package statuscake
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/StatusCakeDev/statuscake-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// errorClass describes why a request to the api failed
+type errorClass string
+
+const (
+	errorClassAPI       errorClass = "api"
+	errorClassAuth      errorClass = "auth"
+	errorClassCancelled errorClass = "cancelled"
+	errorClassDecode    errorClass = "decode"
+	errorClassNetwork   errorClass = "network"
+	errorClassTimeout   errorClass = "timeout"
+	errorClassTLS       errorClass = "tls"
+	errorClassUnknown   errorClass = "unknown"
+)
+
+// transient determines if a request that failed with this class of error might
+// succeed if it's sent again. A TLS error won't go away by itself, as the
+// certificate will still be the same
+func (c errorClass) transient() bool {
+	return c == errorClassNetwork || c == errorClassTimeout
+}
+
+// classifyError determines why a request to the api failed, from the error
+// returned by either the api client or the http transport
+func classifyError(err error) errorClass {
+	var apiError statuscake.APIError
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return errorClassCancelled
+	case errors.Is(err, context.DeadlineExceeded):
+		return errorClassTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return errorClassTimeout
+	case isTLSError(err):
+		return errorClassTLS
+	case isDecodeError(err):
+		return errorClassDecode
+	case errors.As(err, &apiError) && apiError.Status != 0:
+		if apiError.Status == http.StatusUnauthorized || apiError.Status == http.StatusForbidden {
+			return errorClassAuth
+		}
+
+		return errorClassAPI
+	case isNetworkError(err):
+		return errorClassNetwork
+	default:
+		return errorClassUnknown
+	}
+}
+
+func isDecodeError(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+		return true
+	}
+
+	// the api client wraps any problem decoding a response, including unexpected
+	// content types, in an api error
+	var apiError statuscake.APIError
+
+	return errors.As(err, &apiError) && strings.HasPrefix(apiError.Message, "failed to deserialise")
+}
+
+func isTLSError(err error) bool {
+	var certErr x509.CertificateInvalidError
+	var hostErr x509.HostnameError
+	var authorityErr x509.UnknownAuthorityError
+	var recordErr tls.RecordHeaderError
+
+	return errors.As(err, &certErr) ||
+		errors.As(err, &hostErr) ||
+		errors.As(err, &authorityErr) ||
+		errors.As(err, &recordErr)
+}
+
+func isNetworkError(err error) bool {
+	var netErr net.Error
+
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// errorDiag returns a diagnostic for an error that didn't come from the api
+// itself, keeping the original message and suggesting what to do about it
+func errorDiag(err error) diag.Diagnostics {
+	var summary, advice string
+
+	switch classifyError(err) {
+	case errorClassCancelled:
+		summary = "Request to the StatusCake API was cancelled"
+		advice = "The operation was interrupted before StatusCake responded, so any change it was making may or may not have been applied. Run terraform again to check."
+	case errorClassTimeout:
+		summary = "Request to the StatusCake API timed out"
+		advice = "The request was cancelled before StatusCake responded. Try again, or increase the timeouts of the resource."
+	case errorClassNetwork:
+		summary = "Could not connect to the StatusCake API"
+		advice = "Check the network connection, any proxy, and that api_url is correct."
+	case errorClassTLS:
+		summary = "Could not establish a secure connection to the StatusCake API"
+		advice = "The TLS certificate of the server wasn't trusted. Check that api_url is correct, and whether a proxy is intercepting TLS connections."
+	case errorClassDecode:
+		summary = "Could not read the response from the StatusCake API"
+		advice = "The response wasn't in the expected format. Check that api_url points at the StatusCake API."
+	case errorClassAuth:
+		summary = "StatusCake API key was rejected"
+		advice = "Check that the API key is correct and hasn't been revoked."
+	default:
+		summary = "Unexpected error from the StatusCake API"
+	}
+
+	detail := err.Error()
+
+	var apiError statuscake.APIError
+
+	if errors.As(err, &apiError) && apiError.Status != 0 {
+		detail = fmt.Sprintf("The StatusCake API returned HTTP %d: %s", apiError.Status, err)
+	}
+
+	if advice != "" {
+		detail = fmt.Sprintf("%s\n\n%s", detail, advice)
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   detail,
+		},
+	}
+}
@@ -0,0 +1,141 @@
+package statuscake
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/StatusCakeDev/statuscake-go"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// timeoutError is a net.Error that has timed out
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	syntaxErr := json.Unmarshal([]byte("<html>"), &struct{}{})
+
+	tests := map[string]struct {
+		err  error
+		want errorClass
+	}{
+		"deadline":           {fmt.Errorf("request: %w", context.DeadlineExceeded), errorClassTimeout},
+		"cancelled":          {fmt.Errorf("request: %w", context.Canceled), errorClassCancelled},
+		"net timeout":        {&net.OpError{Op: "read", Err: timeoutError{}}, errorClassTimeout},
+		"dns":                {&net.DNSError{Err: "no such host", Name: "api.statuscake.com"}, errorClassNetwork},
+		"connection refused": {&net.OpError{Op: "dial", Err: errors.New("connection refused")}, errorClassNetwork},
+		"unknown authority":  {&url.Error{Op: "Get", URL: "https://api.statuscake.com", Err: x509.UnknownAuthorityError{}}, errorClassTLS},
+		"hostname mismatch":  {&url.Error{Op: "Get", URL: "https://api.statuscake.com", Err: x509.HostnameError{Host: "api.statuscake.com"}}, errorClassTLS},
+		"invalid cert":       {x509.CertificateInvalidError{Reason: x509.Expired}, errorClassTLS},
+		"eof":                {io.ErrUnexpectedEOF, errorClassNetwork},
+		"json":               {syntaxErr, errorClassDecode},
+		"client decode":      {statuscake.NewAPIError("failed to deserialise response body", syntaxErr), errorClassDecode},
+		"unauthorized":       {statuscake.APIError{Status: http.StatusUnauthorized, Message: "Unauthorized"}, errorClassAuth},
+		"forbidden":          {statuscake.APIError{Status: http.StatusForbidden, Message: "Forbidden"}, errorClassAuth},
+		"validation":         {statuscake.APIError{Status: http.StatusBadRequest, Message: "Invalid"}, errorClassAPI},
+		"unknown":            {errors.New("boom"), errorClassUnknown},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := classifyError(tt.err); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestShouldRetryRequest_usesErrorClass(t *testing.T) {
+	t.Parallel()
+
+	get, _ := http.NewRequest(http.MethodGet, "https://api.statuscake.com/v1/uptime", nil)
+
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"network": {&net.DNSError{Err: "no such host"}, true},
+		"timeout": {&net.OpError{Op: "read", Err: timeoutError{}}, true},
+		"eof":     {io.EOF, true},
+		"tls":     {&url.Error{Op: "Get", URL: "https://api.statuscake.com", Err: x509.UnknownAuthorityError{}}, false},
+		"unknown": {errors.New("boom"), false},
+	}
+
+	for name, tt := range tests {
+		if got := shouldRetryRequest(get, nil, tt.err); got != tt.want {
+			t.Errorf("%s: expected %t, got %t", name, tt.want, got)
+		}
+	}
+}
+
+func TestAPIErrorDiag_nonAPIErrors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`<html>not json</html>`))
+	}))
+	defer server.Close()
+
+	closed := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	closed.Close()
+
+	tests := map[string]struct {
+		url         string
+		wantSummary string
+	}{
+		"decode":  {server.URL, "Could not read the response from the StatusCake API"},
+		"network": {closed.URL, "Could not connect to the StatusCake API"},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			client := statuscake.NewAPIClient("my-api-key")
+			client.GetConfig().Servers = statuscake.ServerConfigurations{{URL: tt.url + "/v1"}}
+
+			_, err := client.GetUptimeTest(context.Background(), "123").Execute()
+			diags := apiErrorDiag(err, uptimeTestAPIFields())
+
+			if len(diags) != 1 || diags[0].Summary != tt.wantSummary {
+				t.Fatalf("expected %q, got %v", tt.wantSummary, diags)
+			}
+
+			// the original error must not be lost
+			if !strings.Contains(diags[0].Detail, err.Error()) {
+				t.Errorf("expected the detail to contain %q, got %q", err, diags[0].Detail)
+			}
+		})
+	}
+}
+
+func TestErrorDiag_cancelled(t *testing.T) {
+	t.Parallel()
+
+	diags := errorDiag(fmt.Errorf("Get \"https://api.statuscake.com/v1/uptime/123\": %w", context.Canceled))
+
+	if len(diags) != 1 || diags[0].Summary != "Request to the StatusCake API was cancelled" {
+		t.Errorf("expected a cancelled diagnostic, got %v", diags)
+	}
+	if strings.Contains(diags[0].Detail, "timeouts") {
+		t.Errorf("expected no advice about timeouts, got %q", diags[0].Detail)
+	}
+}
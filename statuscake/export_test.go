@@ -0,0 +1,83 @@
+package statuscake
+
+import (
+	"context"
+	"github.com/StatusCakeDev/statuscake-go"
+)
+
+// the acceptance tests are in the statuscake_test package, so these export the
+// paginated lists for them to check what exists in StatusCake
+
+type HeartbeatTest = heartbeatTest
+
+func ListAllUptimeTests(ctx context.Context, client *statuscake.APIClient) ([]statuscake.UptimeTestOverview, error) {
+	var all []statuscake.UptimeTestOverview
+
+	err := listUptimeTestPages(ctx, client, defaultPageSize, func(page []statuscake.UptimeTestOverview) bool {
+		all = append(all, page...)
+
+		return true
+	})
+
+	return all, err
+}
+
+func ListAllHeartbeatTests(ctx context.Context, client *statuscake.APIClient) ([]HeartbeatTest, error) {
+	var all []HeartbeatTest
+
+	err := listHeartbeatTestPages(ctx, client, defaultPageSize, func(page []heartbeatTest) bool {
+		all = append(all, page...)
+
+		return true
+	})
+
+	return all, err
+}
+
+func ListAllSSLTests(ctx context.Context, client *statuscake.APIClient) ([]statuscake.SSLTest, error) {
+	var all []statuscake.SSLTest
+
+	err := listSSLTestPages(ctx, client, func(page []statuscake.SSLTest) bool {
+		all = append(all, page...)
+
+		return true
+	})
+
+	return all, err
+}
+
+func ListAllPagespeedTests(ctx context.Context, client *statuscake.APIClient) ([]statuscake.PagespeedTest, error) {
+	var all []statuscake.PagespeedTest
+
+	err := listPagespeedTestPages(ctx, client, func(page []statuscake.PagespeedTest) bool {
+		all = append(all, page...)
+
+		return true
+	})
+
+	return all, err
+}
+
+func ListAllContactGroups(ctx context.Context, client *statuscake.APIClient) ([]statuscake.ContactGroup, error) {
+	var all []statuscake.ContactGroup
+
+	err := listContactGroupPages(ctx, client, func(page []statuscake.ContactGroup) bool {
+		all = append(all, page...)
+
+		return true
+	})
+
+	return all, err
+}
+
+func ListAllMaintenanceWindows(ctx context.Context, client *statuscake.APIClient) ([]statuscake.MaintenanceWindow, error) {
+	var all []statuscake.MaintenanceWindow
+
+	err := listMaintenanceWindowPages(ctx, client, func(page []statuscake.MaintenanceWindow) bool {
+		all = append(all, page...)
+
+		return true
+	})
+
+	return all, err
+}
@@ -0,0 +1,146 @@
+package statuscake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/StatusCakeDev/statuscake-go"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// the statuscake-go client doesn't (yet) support heartbeat tests, so these
+// requests are made by hand using the configuration of the shared client so
+// that they're sent to the same server with the same credentials
+
+type heartbeatTest struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Period        int32    `json:"period"`
+	ContactGroups []string `json:"contact_groups"`
+	Tags          []string `json:"tags"`
+	Host          *string  `json:"host,omitempty"`
+	Paused        bool     `json:"paused"`
+	URL           string   `json:"url"`
+}
+
+type heartbeatTestResponse struct {
+	Data heartbeatTest `json:"data"`
+}
+
+type heartbeatTests struct {
+	Data     []heartbeatTest      `json:"data"`
+	Metadata *statuscake.Metadata `json:"metadata,omitempty"`
+}
+
+func createHeartbeatTest(ctx context.Context, client *statuscake.APIClient, form url.Values) (statuscake.APIResponse, error) {
+	var res statuscake.APIResponse
+
+	err := doHeartbeatRequest(ctx, client, http.MethodPost, "/heartbeat", nil, form, &res)
+
+	return res, err
+}
+
+func getHeartbeatTest(ctx context.Context, client *statuscake.APIClient, id string) (heartbeatTestResponse, error) {
+	var res heartbeatTestResponse
+
+	err := doHeartbeatRequest(ctx, client, http.MethodGet, "/heartbeat/"+url.PathEscape(id), nil, nil, &res)
+
+	return res, err
+}
+
+func listHeartbeatTests(ctx context.Context, client *statuscake.APIClient, query url.Values) (heartbeatTests, error) {
+	var res heartbeatTests
+
+	err := doHeartbeatRequest(ctx, client, http.MethodGet, "/heartbeat", query, nil, &res)
+
+	return res, err
+}
+
+func updateHeartbeatTest(ctx context.Context, client *statuscake.APIClient, id string, form url.Values) error {
+	return doHeartbeatRequest(ctx, client, http.MethodPut, "/heartbeat/"+url.PathEscape(id), nil, form, nil)
+}
+
+func deleteHeartbeatTest(ctx context.Context, client *statuscake.APIClient, id string) error {
+	return doHeartbeatRequest(ctx, client, http.MethodDelete, "/heartbeat/"+url.PathEscape(id), nil, nil, nil)
+}
+
+func doHeartbeatRequest(ctx context.Context, client *statuscake.APIClient, method, path string, query, form url.Values, out interface{}) error {
+	cfg := client.GetConfig()
+
+	basePath, err := cfg.ServerURL(0, nil)
+
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(basePath + path)
+
+	if err != nil {
+		return err
+	}
+
+	if cfg.Host != "" {
+		u.Host = cfg.Host
+	}
+	if cfg.Scheme != "" {
+		u.Scheme = cfg.Scheme
+	}
+
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), strings.NewReader(form.Encode()))
+
+	if err != nil {
+		return err
+	}
+
+	for k, v := range cfg.DefaultHeader {
+		req.Header.Set(k, v)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", cfg.UserAgent)
+
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	res, err := cfg.HTTPClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode >= 300 {
+		apiError := statuscake.APIError{}
+
+		if err := json.Unmarshal(body, &apiError); err != nil {
+			apiError = statuscake.NewAPIError("failed to deserialise error response", err)
+		}
+
+		apiError.Status = res.StatusCode
+
+		return apiError
+	}
+
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to deserialise response body: %w", err)
+	}
+
+	return nil
+}
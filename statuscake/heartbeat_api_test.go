@@ -0,0 +1,97 @@
+package statuscake
+
+import (
+	"context"
+	"github.com/StatusCakeDev/statuscake-go"
+	"github.com/hashicorp/go-cty/cty"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func newTestAPIClient(t *testing.T, handler http.HandlerFunc) *statuscake.APIClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := statuscake.NewAPIClient("my-api-key")
+	client.GetConfig().Servers = statuscake.ServerConfigurations{{URL: server.URL + "/v1"}}
+
+	return client
+}
+
+func TestCreateHeartbeatTest(t *testing.T) {
+	t.Parallel()
+
+	client := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/heartbeat" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer my-api-key" {
+			t.Errorf("unexpected authorization header: %s", got)
+		}
+		if got := r.PostFormValue("tags_csv"); got != "one,two" {
+			t.Errorf("unexpected tags_csv: %s", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"new_id":"123"}}`))
+	})
+
+	res, err := createHeartbeatTest(context.Background(), client, url.Values{"tags_csv": {"one,two"}})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if res.Data.NewID != "123" {
+		t.Errorf("expected new id to be 123, got %s", res.Data.NewID)
+	}
+}
+
+func TestGetHeartbeatTest(t *testing.T) {
+	t.Parallel()
+
+	client := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"id":"123","name":"My Job","period":1800,"url":"https://push.statuscake.com/?PK=abc"}}`))
+	})
+
+	res, err := getHeartbeatTest(context.Background(), client, "123")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if res.Data.URL != "https://push.statuscake.com/?PK=abc" {
+		t.Errorf("unexpected url: %s", res.Data.URL)
+	}
+}
+
+func TestHeartbeatTestErrors(t *testing.T) {
+	t.Parallel()
+
+	client := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"No results found","errors":{}}`))
+
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"The provided parameters are invalid","errors":{"period":["Period must be at least 30"]}}`))
+	})
+
+	if _, err := getHeartbeatTest(context.Background(), client, "123"); !isNotFoundAPIError(err) {
+		t.Errorf("expected a not found api error, got %v", err)
+	}
+
+	diags := apiErrorDiag(updateHeartbeatTest(context.Background(), client, "123", url.Values{"period": {"10"}}), heartbeatTestAPIFields())
+
+	if len(diags) != 1 || diags[0].Summary != "Period must be at least 30" || !diags[0].AttributePath.Equals(cty.GetAttrPath("period")) {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
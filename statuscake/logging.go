@@ -0,0 +1,204 @@
+package statuscake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/StatusCakeDev/statuscake-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// logSubsystemAPI logs each request made to the StatusCake API, and can be
+	// configured with TF_LOG_PROVIDER_STATUSCAKE_API
+	logSubsystemAPI = "api"
+
+	// logSubsystemResource logs what the resources get back from the StatusCake
+	// API, and can be configured with TF_LOG_PROVIDER_STATUSCAKE_RESOURCE
+	logSubsystemResource = "resource"
+
+	redactedLogValue = "***"
+)
+
+// sensitiveLogFields are the fields, both in requests & responses, that can hold
+// passwords, tokens or other secrets and so must never be logged
+var sensitiveLogFields = []string{ //nolint:gochecknoglobals
+	"api_key",
+	"authorization",
+	"basic_pass",
+	"custom_header",
+	"ping_url",
+	"post_body",
+	"post_raw",
+	"url",
+}
+
+// withLogging returns a context with the provider's log subsystems, which mask
+// any sensitive fields as a safeguard on top of the values being redacted
+func withLogging(ctx context.Context) context.Context {
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, sensitiveLogFields...)
+
+	for _, subsystem := range []string{logSubsystemAPI, logSubsystemResource} {
+		ctx = tflog.NewSubsystem(
+			ctx,
+			subsystem,
+			tflog.WithRootFields(),
+			tflog.WithLevelFromEnv("TF_LOG_PROVIDER_STATUSCAKE", strings.ToUpper(subsystem)),
+		)
+		ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, subsystem, sensitiveLogFields...)
+	}
+
+	return ctx
+}
+
+// withResourceLogging wraps each operation of a resource so that everything
+// logged during it includes the operation and the id of the resource
+func withResourceLogging(r *schema.Resource) *schema.Resource {
+	type operationFunc = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+	wrap := func(operation string, fn operationFunc) operationFunc {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			ctx = tflog.SetField(ctx, "operation", operation)
+			ctx = tflog.SetField(ctx, "resource_id", d.Id())
+
+			return fn(withLogging(ctx), d, meta)
+		}
+	}
+
+	r.CreateContext = wrap("create", r.CreateContext)
+	r.ReadContext = wrap("read", r.ReadContext)
+	r.UpdateContext = wrap("update", r.UpdateContext)
+	r.DeleteContext = wrap("delete", r.DeleteContext)
+
+	return r
+}
+
+func isSensitiveLogField(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, field := range sensitiveLogFields {
+		if key == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactObject returns the object as generic JSON values with the value of every
+// sensitive field replaced, however deeply it is nested
+func redactObject(obj interface{}) interface{} {
+	raw, err := json.Marshal(obj)
+
+	if err != nil {
+		return redactedLogValue
+	}
+
+	var value interface{}
+
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return redactedLogValue
+	}
+
+	return redactValue(value)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSensitiveLogField(key) && item != nil {
+				v[key] = redactedLogValue
+			} else {
+				v[key] = redactValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+
+	return value
+}
+
+// redactForm returns the encoded form with the value of every sensitive field
+// replaced
+func redactForm(body string) string {
+	form, err := url.ParseQuery(body)
+
+	if err != nil {
+		return redactedLogValue
+	}
+
+	for key := range form {
+		if isSensitiveLogField(key) || isSensitiveLogField(strings.TrimSuffix(key, "[]")) {
+			form[key] = []string{redactedLogValue}
+		}
+	}
+
+	return form.Encode()
+}
+
+func logResponse(ctx context.Context, res interface{}) {
+	tflog.SubsystemDebug(ctx, logSubsystemResource, "StatusCake API response", map[string]interface{}{
+		"response": redactObject(res),
+	})
+}
+
+func logStatusCakeAPIError(ctx context.Context, err error) {
+	var statuscakeError statuscake.APIError
+
+	if !errors.As(err, &statuscakeError) {
+		tflog.SubsystemWarn(ctx, logSubsystemResource, "StatusCake API request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystemResource, "StatusCake API returned an error", map[string]interface{}{
+		"http_status": statuscakeError.Status,
+		"message":     statuscakeError.Message,
+		"errors":      redactObject(statuscakeError.Errors),
+	})
+}
+
+// loggingTransport logs every request sent to the StatusCake API, without the
+// headers or body as they can hold the api key and other secrets
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+
+	res, err := t.next.RoundTrip(req)
+
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_path":   req.URL.Path,
+		"latency_ms":  time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+
+		tflog.SubsystemDebug(ctx, logSubsystemAPI, "StatusCake API request failed", fields)
+
+		return nil, err
+	}
+
+	fields["http_status"] = res.StatusCode
+
+	tflog.SubsystemDebug(ctx, logSubsystemAPI, "StatusCake API request", fields)
+
+	return res, nil
+}
@@ -0,0 +1,184 @@
+package statuscake
+
+import (
+	"bytes"
+	"context"
+	"github.com/StatusCakeDev/statuscake-go"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testLogSecrets are the values that must never appear in the logs
+var testLogSecrets = []string{ //nolint:gochecknoglobals
+	"Bearer my-api-key",
+	"my-api-key",
+	"hunter2",
+	"X-Token: abc123",
+	`{"token":"s3cr3t"}`,
+	"https://example.com/hook?token=xyz789",
+}
+
+func assertNoSecrets(t *testing.T, output string) {
+	t.Helper()
+
+	for _, secret := range testLogSecrets {
+		if strings.Contains(output, secret) {
+			t.Errorf("expected %q to not be logged, got %s", secret, output)
+		}
+	}
+}
+
+func TestRedactObject(t *testing.T) {
+	t.Parallel()
+
+	header := "X-Token: abc123"
+	body := `{"token":"s3cr3t"}`
+	pingURL := "https://example.com/hook?token=xyz789"
+
+	redacted := redactObject(map[string]interface{}{
+		"data": statuscake.UptimeTest{
+			Name:         "My Site",
+			CustomHeader: &header,
+			PostBody:     &body,
+		},
+		"groups": []statuscake.ContactGroup{
+			{Name: "Operations Team", PingURL: &pingURL},
+		},
+	})
+
+	data := redacted.(map[string]interface{})["data"].(map[string]interface{})
+
+	if data["name"] != "My Site" {
+		t.Errorf("expected the name to be kept, got %v", data["name"])
+	}
+	if data["custom_header"] != redactedLogValue || data["post_body"] != redactedLogValue {
+		t.Errorf("expected the custom header and post body to be redacted, got %v", data)
+	}
+
+	group := redacted.(map[string]interface{})["groups"].([]interface{})[0].(map[string]interface{})
+
+	if group["ping_url"] != redactedLogValue {
+		t.Errorf("expected the nested ping url to be redacted, got %v", group["ping_url"])
+	}
+}
+
+func TestRedactForm(t *testing.T) {
+	t.Parallel()
+
+	got := redactForm("name=My+Site&basic_pass=hunter2&custom_header=X-Token%3A+abc123&tags%5B%5D=web")
+
+	if !strings.Contains(got, "name=My+Site") || !strings.Contains(got, "tags%5B%5D=web") {
+		t.Errorf("expected the other fields to be kept, got %s", got)
+	}
+	if strings.Contains(got, "hunter2") || strings.Contains(got, "abc123") {
+		t.Errorf("expected the secrets to be redacted, got %s", got)
+	}
+}
+
+func TestLogging_neverLogsSecrets(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{
+			"id":"123",
+			"name":"My Site",
+			"test_type":"HTTP",
+			"website_url":"https://www.example.com",
+			"check_rate":300,
+			"custom_header":"X-Token: abc123",
+			"post_body":"{\"token\":\"s3cr3t\"}",
+			"contact_groups":[],
+			"servers":[],
+			"status_codes":[],
+			"tags":[]
+		}}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := statuscake.NewAPIClient("my-api-key")
+	cfg := client.GetConfig()
+	cfg.Servers = statuscake.ServerConfigurations{{URL: server.URL + "/v1"}}
+	cfg.HTTPClient = &http.Client{Transport: &loggingTransport{next: http.DefaultTransport}}
+
+	r := withResourceLogging(ResourceStatusCakeUptimeTest())
+	d := r.TestResourceData()
+	d.SetId("123")
+
+	if diags := r.ReadContext(ctx, d, &providerMeta{client: client}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	logs, err := tflogtest.MultilineJSONDecode(&output)
+
+	if err != nil {
+		t.Fatalf("unexpected error decoding the logs: %s", err)
+	}
+
+	var request, response map[string]interface{}
+
+	for _, entry := range logs {
+		switch entry["@message"] {
+		case "StatusCake API request":
+			request = entry
+		case "StatusCake API response":
+			response = entry
+		}
+	}
+
+	if request == nil || response == nil {
+		t.Fatalf("expected the request and response to be logged, got %v", logs)
+	}
+
+	for k, want := range map[string]interface{}{
+		"@module":     "provider.api",
+		"operation":   "read",
+		"resource_id": "123",
+		"http_method": "GET",
+		"http_path":   "/v1/uptime/123",
+		"http_status": float64(200),
+	} {
+		if request[k] != want {
+			t.Errorf("expected %s to be %v, got %v", k, want, request[k])
+		}
+	}
+
+	if _, ok := request["latency_ms"]; !ok {
+		t.Error("expected the latency to be logged")
+	}
+
+	if response["@module"] != "provider.resource" {
+		t.Errorf("expected the response to be logged by the resource subsystem, got %v", response["@module"])
+	}
+
+	assertNoSecrets(t, output.String())
+}
+
+func TestLogging_masksSensitiveFields(t *testing.T) {
+	t.Parallel()
+
+	var output bytes.Buffer
+
+	ctx := withLogging(tflogtest.RootLogger(context.Background(), &output))
+
+	logStatusCakeAPIError(ctx, statuscake.APIError{
+		Status:  400,
+		Message: "The provided parameters are invalid",
+		Errors: map[string][]string{
+			"basic_pass": {"hunter2 is too short"},
+		},
+	})
+
+	if !strings.Contains(output.String(), "The provided parameters are invalid") {
+		t.Errorf("expected the error to be logged, got %s", output.String())
+	}
+
+	assertNoSecrets(t, output.String())
+}
@@ -0,0 +1,139 @@
+package statuscake
+
+import (
+	"context"
+	"github.com/StatusCakeDev/statuscake-go"
+	"net/url"
+	"strconv"
+)
+
+// defaultPageSize is the largest page the api returns
+const defaultPageSize = 100
+
+// pageFunc requests a single page of a list, and returns the metadata of the
+// page along with whether the next page should be requested
+type pageFunc func(ctx context.Context, page, pageSize int32) (metadata *statuscake.Metadata, more bool, err error)
+
+// paginate requests each page of a list in turn, starting from the first, until
+// either the last page has been requested, fetch asks to stop, or the context
+// is done. A pageSize of 0 or less uses the largest page the api returns.
+//
+// lists the api doesn't paginate have no metadata, and so are a single page
+func paginate(ctx context.Context, pageSize int32, fetch pageFunc) error {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	for page := int32(1); ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		metadata, more, err := fetch(ctx, page, pageSize)
+
+		if err != nil {
+			return err
+		}
+
+		if !more || isLastPage(page, metadata) {
+			return nil
+		}
+	}
+}
+
+func isLastPage(page int32, metadata *statuscake.Metadata) bool {
+	return metadata == nil || metadata.PageCount == nil || page >= *metadata.PageCount
+}
+
+// listUptimeTestPages calls fn with each page of uptime tests until fn returns
+// false
+func listUptimeTestPages(ctx context.Context, client *statuscake.APIClient, pageSize int32, fn func([]statuscake.UptimeTestOverview) bool) error {
+	return paginate(ctx, pageSize, func(ctx context.Context, page, pageSize int32) (*statuscake.Metadata, bool, error) {
+		res, err := client.ListUptimeTests(ctx).
+			Page(page).
+			Limit(pageSize).
+			Execute()
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		return res.Metadata, fn(res.Data), nil
+	})
+}
+
+// listHeartbeatTestPages calls fn with each page of heartbeat tests until fn
+// returns false
+func listHeartbeatTestPages(ctx context.Context, client *statuscake.APIClient, pageSize int32, fn func([]heartbeatTest) bool) error {
+	return paginate(ctx, pageSize, func(ctx context.Context, page, pageSize int32) (*statuscake.Metadata, bool, error) {
+		res, err := listHeartbeatTests(ctx, client, url.Values{
+			"page":  {strconv.Itoa(int(page))},
+			"limit": {strconv.Itoa(int(pageSize))},
+		})
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		return res.Metadata, fn(res.Data), nil
+	})
+}
+
+// the statuscake-go client can't request a page of the other lists, and they're
+// returned without any metadata, so each of them is a single page of every item
+// and can't be given a page size
+
+// listSSLTestPages calls fn with each page of SSL tests until fn returns false
+func listSSLTestPages(ctx context.Context, client *statuscake.APIClient, fn func([]statuscake.SSLTest) bool) error {
+	return paginate(ctx, 0, func(ctx context.Context, _, _ int32) (*statuscake.Metadata, bool, error) {
+		res, err := client.ListSslTests(ctx).Execute()
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		return nil, fn(res.Data), nil
+	})
+}
+
+// listPagespeedTestPages calls fn with each page of pagespeed tests until fn
+// returns false
+func listPagespeedTestPages(ctx context.Context, client *statuscake.APIClient, fn func([]statuscake.PagespeedTest) bool) error {
+	return paginate(ctx, 0, func(ctx context.Context, _, _ int32) (*statuscake.Metadata, bool, error) {
+		res, err := client.ListPagespeedTests(ctx).Execute()
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		return nil, fn(res.Data), nil
+	})
+}
+
+// listContactGroupPages calls fn with each page of contact groups until fn
+// returns false
+func listContactGroupPages(ctx context.Context, client *statuscake.APIClient, fn func([]statuscake.ContactGroup) bool) error {
+	return paginate(ctx, 0, func(ctx context.Context, _, _ int32) (*statuscake.Metadata, bool, error) {
+		res, err := client.ListContactGroups(ctx).Execute()
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		return nil, fn(res.Data), nil
+	})
+}
+
+// listMaintenanceWindowPages calls fn with each page of maintenance windows until
+// fn returns false
+func listMaintenanceWindowPages(ctx context.Context, client *statuscake.APIClient, fn func([]statuscake.MaintenanceWindow) bool) error {
+	return paginate(ctx, 0, func(ctx context.Context, _, _ int32) (*statuscake.Metadata, bool, error) {
+		res, err := client.ListMaintenanceWindows(ctx).Execute()
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		return nil, fn(res.Data), nil
+	})
+}
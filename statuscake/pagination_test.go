@@ -0,0 +1,285 @@
+package statuscake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/StatusCakeDev/statuscake-go"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// fakePages returns a page func for a list with the given number of pages,
+// recording each page that was requested
+func fakePages(pageCount *int32, requested *[]int32, stopAt int32) pageFunc {
+	return func(_ context.Context, page, _ int32) (*statuscake.Metadata, bool, error) {
+		*requested = append(*requested, page)
+
+		var metadata *statuscake.Metadata
+
+		if pageCount != nil {
+			metadata = &statuscake.Metadata{Page: statuscake.PtrInt32(page), PageCount: pageCount}
+		}
+
+		return metadata, page != stopAt, nil
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		pageCount *int32
+		stopAt    int32
+		want      []int32
+	}{
+		"no results":       {statuscake.PtrInt32(0), 0, []int32{1}},
+		"single page":      {statuscake.PtrInt32(1), 0, []int32{1}},
+		"last page":        {statuscake.PtrInt32(3), 0, []int32{1, 2, 3}},
+		"without metadata": {nil, 0, []int32{1}},
+		"stopped early":    {statuscake.PtrInt32(3), 2, []int32{1, 2}},
+		"stopped on last":  {statuscake.PtrInt32(3), 3, []int32{1, 2, 3}},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var requested []int32
+
+			if err := paginate(context.Background(), 0, fakePages(tt.pageCount, &requested, tt.stopAt)); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(requested, tt.want) {
+				t.Errorf("expected pages %v, got %v", tt.want, requested)
+			}
+		})
+	}
+}
+
+func TestPaginate_pageSize(t *testing.T) {
+	t.Parallel()
+
+	for pageSize, want := range map[int32]int32{0: defaultPageSize, -1: defaultPageSize, 25: 25} {
+		var got int32
+
+		_ = paginate(context.Background(), pageSize, func(_ context.Context, _, pageSize int32) (*statuscake.Metadata, bool, error) {
+			got = pageSize
+
+			return nil, false, nil
+		})
+
+		if got != want {
+			t.Errorf("expected a page size of %d to request %d, got %d", pageSize, want, got)
+		}
+	}
+}
+
+func TestPaginate_error(t *testing.T) {
+	t.Parallel()
+
+	errPage := errors.New("page failed")
+	var requested []int32
+
+	err := paginate(context.Background(), 0, func(ctx context.Context, page, pageSize int32) (*statuscake.Metadata, bool, error) {
+		if page == 2 {
+			return nil, false, errPage
+		}
+
+		return fakePages(statuscake.PtrInt32(3), &requested, 0)(ctx, page, pageSize)
+	})
+
+	if !errors.Is(err, errPage) {
+		t.Errorf("expected the error of the page, got %v", err)
+	}
+	if !reflect.DeepEqual(requested, []int32{1}) {
+		t.Errorf("expected no more pages to be requested, got %v", requested)
+	}
+}
+
+func TestPaginate_stopsWhenContextIsCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var requested []int32
+
+	err := paginate(ctx, 0, func(ctx context.Context, page, pageSize int32) (*statuscake.Metadata, bool, error) {
+		cancel()
+
+		return fakePages(statuscake.PtrInt32(3), &requested, 0)(ctx, page, pageSize)
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the context to be cancelled, got %v", err)
+	}
+	if !reflect.DeepEqual(requested, []int32{1}) {
+		t.Errorf("expected no more pages to be requested, got %v", requested)
+	}
+}
+
+// pagedHandler serves a list of the given number of items, with ids counting up
+// from 1, split into pages of the requested limit
+func pagedHandler(t *testing.T, path string, items int, queries *[]string) http.HandlerFunc {
+	var mu sync.Mutex
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		mu.Lock()
+		*queries = append(*queries, r.URL.RawQuery)
+		mu.Unlock()
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		var data string
+
+		for id := (page-1)*limit + 1; id <= page*limit && id <= items; id++ {
+			if data != "" {
+				data += ","
+			}
+
+			data += fmt.Sprintf(`{"id":"%d"}`, id)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data":[%s],"metadata":{"page":%d,"per_page":%d,"page_count":%d,"total_count":%d}}`,
+			data, page, limit, (items+limit-1)/limit, items)
+	}
+}
+
+func TestListUptimeTestPages(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		items    int
+		wantIDs  int
+		wantReqs []string
+	}{
+		"empty":              {0, 0, []string{"limit=2&page=1"}},
+		"partial last page":  {5, 5, []string{"limit=2&page=1", "limit=2&page=2", "limit=2&page=3"}},
+		"exactly full pages": {4, 4, []string{"limit=2&page=1", "limit=2&page=2"}},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var queries []string
+			var ids []string
+
+			client := newTestAPIClient(t, pagedHandler(t, "/v1/uptime", tt.items, &queries))
+
+			err := listUptimeTestPages(context.Background(), client, 2, func(page []statuscake.UptimeTestOverview) bool {
+				for _, test := range page {
+					ids = append(ids, test.ID)
+				}
+
+				return true
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(ids) != tt.wantIDs {
+				t.Errorf("expected %d uptime tests, got %v", tt.wantIDs, ids)
+			}
+			if !reflect.DeepEqual(queries, tt.wantReqs) {
+				t.Errorf("expected requests %v, got %v", tt.wantReqs, queries)
+			}
+		})
+	}
+}
+
+func TestListHeartbeatTestPages(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	var ids []string
+
+	client := newTestAPIClient(t, pagedHandler(t, "/v1/heartbeat", 5, &queries))
+
+	// stop as soon as the test being looked for is found
+	err := listHeartbeatTestPages(context.Background(), client, 2, func(page []heartbeatTest) bool {
+		for _, test := range page {
+			ids = append(ids, test.ID)
+
+			if test.ID == "3" {
+				return false
+			}
+		}
+
+		return true
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(ids, []string{"1", "2", "3"}) {
+		t.Errorf("expected heartbeat tests up to 3, got %v", ids)
+	}
+	if want := []string{"limit=2&page=1", "limit=2&page=2"}; !reflect.DeepEqual(queries, want) {
+		t.Errorf("expected requests %v, got %v", want, queries)
+	}
+}
+
+func TestListUnpaginatedPages(t *testing.T) {
+	t.Parallel()
+
+	lists := map[string]func(context.Context, *statuscake.APIClient, *int) error{
+		"/v1/contact-groups": func(ctx context.Context, client *statuscake.APIClient, n *int) error {
+			return listContactGroupPages(ctx, client, func(page []statuscake.ContactGroup) bool { *n += len(page); return true })
+		},
+		"/v1/maintenance-windows": func(ctx context.Context, client *statuscake.APIClient, n *int) error {
+			return listMaintenanceWindowPages(ctx, client, func(page []statuscake.MaintenanceWindow) bool { *n += len(page); return true })
+		},
+		"/v1/pagespeed": func(ctx context.Context, client *statuscake.APIClient, n *int) error {
+			return listPagespeedTestPages(ctx, client, func(page []statuscake.PagespeedTest) bool { *n += len(page); return true })
+		},
+		"/v1/ssl": func(ctx context.Context, client *statuscake.APIClient, n *int) error {
+			return listSSLTestPages(ctx, client, func(page []statuscake.SSLTest) bool { *n += len(page); return true })
+		},
+	}
+
+	for path, list := range lists {
+		path, list := path, list
+
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+
+			var requests int
+
+			client := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+
+				if r.URL.Path != path {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"data":[{"id":"1"},{"id":"2"},{"id":"3"}]}`))
+			})
+
+			var n int
+
+			if err := list(context.Background(), client, &n); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if n != 3 || requests != 1 {
+				t.Errorf("expected all 3 items from a single request, got %d from %d requests", n, requests)
+			}
+		})
+	}
+}
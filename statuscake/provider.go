@@ -2,11 +2,36 @@ package statuscake
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/StatusCakeDev/statuscake-go"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/http"
+	"strings"
+	"time"
 )
 
+const defaultAPIURL = "https://api.statuscake.com/v1"
+
+// providerMeta is what gets passed to every resource & data source once the
+// provider has been configured
+type providerMeta struct {
+	client      *statuscake.APIClient
+	defaultTags []string
+
+	uptimeTestDefaults map[string]interface{}
+
+	readOnly bool
+
+	readAfterWriteTimeout time.Duration
+
+	// uptimeTests is shared by every uptime test refreshed during the run
+	uptimeTests *uptimeTestCache
+}
+
 func New(version string) func() *schema.Provider {
 	return func() *schema.Provider {
 		p := &schema.Provider{
@@ -16,15 +41,143 @@ func New(version string) func() *schema.Provider {
 					Optional:    true,
 					Sensitive:   true,
 					DefaultFunc: schema.EnvDefaultFunc("STATUSCAKE_API_KEY", nil),
+					Description: "StatusCake API key. Can also be set with the STATUSCAKE_API_KEY environment variable, and takes precedence over `api_key_command` and the credentials file",
+				},
+				"api_key_command": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Command to run with the shell to get the API key, which the command should write to stdout. Used when `api_key` isn't set, and takes precedence over the credentials file",
+				},
+				"api_url": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("STATUSCAKE_API_URL", defaultAPIURL),
+					ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+					Description:  "Base URL of the StatusCake API, including the version. Can also be set with the STATUSCAKE_API_URL environment variable",
+				},
+				"credentials_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("STATUSCAKE_CREDENTIALS_FILE", defaultCredentialsFile),
+					Description: "Path to the INI file with the API key of each profile, used when neither `api_key` nor `api_key_command` are set. Defaults to ~/.statuscake/credentials, and can also be set with the STATUSCAKE_CREDENTIALS_FILE environment variable",
+				},
+				"custom_headers": {
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional:         true,
+					ValidateDiagFunc: validateProviderHeaders,
+					Description:      "Additional HTTP headers to send with every request made to the StatusCake API. Authorization, Content-Type and User-Agent are set by the provider and can't be overridden",
+				},
+				"default_tags": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"tags": {
+								Type: schema.TypeList,
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+								},
+								Optional:    true,
+								Description: "List of tags to add to every uptime test",
+							},
+						},
+					},
+					Description: "Tags that are added to every uptime test managed by the provider, in addition to the tags of the test itself",
+				},
+				"max_retries": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      4,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "Maximum number of times to retry a request that was rate limited or failed with a server or connection error. Set to 0 to disable retries",
+				},
+				"max_retry_wait": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      30,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Maximum number of seconds to wait between retries",
+				},
+				"profile": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("STATUSCAKE_PROFILE", nil),
+					Description: "Profile in the credentials file to get the API key from. Defaults to `default`, and can also be set with the STATUSCAKE_PROFILE environment variable",
+				},
+				"read_after_write_timeout": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      10,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "Number of seconds to keep retrying the read that follows a create or update when the StatusCake API doesn't find the object yet. Set to 0 to disable",
+				},
+				"read_only": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Fail any attempt to create, update or delete a resource before anything is sent to the StatusCake API. Reads and data sources still work",
+				},
+				"requests_per_second": {
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.FloatAtLeast(0),
+					Description:  "Maximum number of requests to make to the StatusCake API per second, shared across all resources and data sources. Set to 0 for no limit",
+				},
+				"dry_run": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Log the requests that would create, update or delete anything instead of sending them to the StatusCake API, which then fail. Requests that only read are still sent",
+				},
+				"max_concurrent_requests": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "Maximum number of requests that can be made to the StatusCake API at the same time, shared across all resources and data sources. Set to 0 for no limit",
+				},
+				"skip_credentials_validation": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Skip checking that the API key is accepted by the StatusCake API when configuring the provider, such as when planning offline against a fake API",
+				},
+				"uptime_test_defaults": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: uptimeTestDefaultsSchema(),
+					},
+					Description: "Default values for uptime tests, used whenever an uptime test doesn't set the attribute itself",
+				},
+				"user_agent_suffix": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("STATUSCAKE_USER_AGENT_SUFFIX", nil),
+					Description: "Text to append to the User-Agent sent with every request, such as the name of the pipeline making the change. Can also be set with the STATUSCAKE_USER_AGENT_SUFFIX environment variable",
 				},
 			},
 			ResourcesMap: map[string]*schema.Resource{
-				"statuscake_contact_group": ResourceStatusCakeContactGroup(),
-				"statuscake_uptime_test":   ResourceStatusCakeUptimeTest(),
+				"statuscake_contact_group":      ResourceStatusCakeContactGroup(),
+				"statuscake_heartbeat_test":     ResourceStatusCakeHeartbeatTest(),
+				"statuscake_maintenance_window": ResourceStatusCakeMaintenanceWindow(),
+				"statuscake_pagespeed_test":     ResourceStatusCakePagespeedTest(),
+				"statuscake_ssl_test":           ResourceStatusCakeSSLTest(),
+				"statuscake_uptime_test":        ResourceStatusCakeUptimeTest(),
 			},
 			DataSourcesMap: map[string]*schema.Resource{},
 		}
 
+		for name, r := range p.ResourcesMap {
+			withResourceLogging(guardWrites(name, invalidateCacheOnWrite(r)))
+		}
+
 		p.ConfigureContextFunc = configure(version, p)
 
 		return p
@@ -33,16 +186,171 @@ func New(version string) func() *schema.Provider {
 
 func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		apiKey := d.Get("api_key").(string)
-
 		var diags diag.Diagnostics
 
+		ctx = withLogging(ctx)
+
+		apiKey, err := resolveAPIKey(ctx, d)
+
+		if err != nil {
+			return nil, diag.Diagnostics{
+				{
+					Severity: diag.Error,
+					Summary:  "Could not load the StatusCake API key",
+					Detail:   err.Error(),
+				},
+			}
+		}
+
 		if apiKey == "" {
-			return nil, diag.Errorf("Missing api_key")
+			return nil, diag.Diagnostics{
+				{
+					Severity: diag.Error,
+					Summary:  "Missing api_key",
+					Detail:   "Set the api_key argument or STATUSCAKE_API_KEY environment variable, the api_key_command argument, or add the key to a profile in the credentials file",
+				},
+			}
 		}
 
 		client := statuscake.NewAPIClient(apiKey)
+		cfg := client.GetConfig()
+
+		cfg.Servers = statuscake.ServerConfigurations{
+			{
+				URL:         strings.TrimSuffix(d.Get("api_url").(string), "/"),
+				Description: "StatusCake API",
+			},
+		}
+
+		cfg.UserAgent = userAgent(version, p.TerraformVersion, d.Get("user_agent_suffix").(string))
+
+		for name, value := range d.Get("custom_headers").(map[string]interface{}) {
+			cfg.AddDefaultHeader(name, value.(string))
+		}
+
+		// each retry is throttled, so that retries don't make rate limiting worse
+		cfg.HTTPClient = &http.Client{
+			Transport: newRetryTransport(
+				newThrottleTransport(
+					&loggingTransport{next: http.DefaultTransport},
+					d.Get("requests_per_second").(float64),
+					d.Get("max_concurrent_requests").(int),
+				),
+				d.Get("max_retries").(int),
+				time.Duration(d.Get("max_retry_wait").(int))*time.Second,
+			),
+		}
+
+		if d.Get("dry_run").(bool) {
+			cfg.HTTPClient.Transport = &dryRunTransport{next: cfg.HTTPClient.Transport}
+		}
+
+		if !d.Get("skip_credentials_validation").(bool) {
+			if diags := validateCredentials(ctx, client); diags.HasError() {
+				return nil, diags
+			}
+		}
+
+		meta := &providerMeta{
+			client:             client,
+			uptimeTestDefaults: expandUptimeTestDefaults(d),
+			readOnly:           d.Get("read_only").(bool),
+
+			readAfterWriteTimeout: time.Duration(d.Get("read_after_write_timeout").(int)) * time.Second,
+			uptimeTests:           &uptimeTestCache{},
+		}
+
+		if v, ok := d.GetOk("default_tags.0.tags"); ok {
+			meta.defaultTags = asListOfStrings(v)
+		}
+
+		return meta, diags
+	}
+}
+
+// reservedProviderHeaders are set by the provider on every request, so that
+// custom headers can't replace the api key or the User-Agent
+var reservedProviderHeaders = []string{ //nolint:gochecknoglobals
+	"Authorization",
+	"Content-Type",
+	"User-Agent",
+}
+
+func validateProviderHeaders(v interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for name := range v.(map[string]interface{}) {
+		canonical := http.CanonicalHeaderKey(name)
+
+		for _, reserved := range reservedProviderHeaders {
+			if canonical == reserved {
+				diags = append(diags, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Reserved header name",
+					Detail:        fmt.Sprintf("The %s header is set by the provider and can't be overridden with custom_headers", reserved),
+					AttributePath: path,
+				})
+			}
+		}
+	}
+
+	return diags
+}
+
+// userAgent returns the User-Agent sent with every request, so that StatusCake
+// can tell which requests were made by the provider
+func userAgent(version, terraformVersion, suffix string) string {
+	if terraformVersion == "" {
+		// terraform 0.12 and later always send their version, so this must be an
+		// older version of terraform
+		terraformVersion = "0.11+compatible"
+	}
+
+	ua := fmt.Sprintf("terraform-provider-statuscake/%s terraform/%s", version, terraformVersion)
+
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		ua += " " + suffix
+	}
+
+	return ua
+}
+
+// validateCredentials makes a cheap request to check that the api key is valid,
+// so that a bad key is reported up front rather than by the first resource
+func validateCredentials(ctx context.Context, client *statuscake.APIClient) diag.Diagnostics {
+	_, err := client.ListUptimeTests(ctx).Limit(1).Execute()
+
+	if err == nil {
+		return nil
+	}
+
+	logStatusCakeAPIError(ctx, err)
+
+	var apiError statuscake.APIError
+
+	if errors.As(err, &apiError) && (apiError.Status == http.StatusUnauthorized || apiError.Status == http.StatusForbidden) {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  "Invalid StatusCake API key",
+				Detail: fmt.Sprintf(
+					"The StatusCake API rejected the API key with HTTP %d (%s). Check that the key is correct and hasn't been revoked, "+
+						"and that it was taken from the intended source: api_key, STATUSCAKE_API_KEY, api_key_command or the credentials file profile.",
+					apiError.Status,
+					apiError.Message,
+				),
+			},
+		}
+	}
 
-		return client, diags
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  "Could not validate the StatusCake API key",
+			Detail: fmt.Sprintf(
+				"Checking the API key with the StatusCake API failed: %s. Set skip_credentials_validation to skip this check.",
+				err,
+			),
+		},
 	}
 }
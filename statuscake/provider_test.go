@@ -1,9 +1,14 @@
 package statuscake_test
 
 import (
+	"context"
 	"github.com/StatusCakeDev/statuscake-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	provider "terraform-provider-statuscake/statuscake"
 	"testing"
 )
@@ -39,3 +44,176 @@ func TestProvider(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 }
+
+func TestProvider_apiURL(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/proxy/v1/contact-groups/123" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.Header.Get("X-Proxy-Token"); got != "secret" {
+			t.Errorf("unexpected X-Proxy-Token header: %s", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer my-api-key" {
+			t.Errorf("unexpected Authorization header: %s", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"id":"123","name":"Operations Team","email_addresses":[],"integrations":[],"mobile_numbers":[]}}`))
+	}))
+	defer server.Close()
+
+	p := provider.New("dev")()
+
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"skip_credentials_validation": true,
+		"api_key":                     "my-api-key",
+		"api_url":                     server.URL + "/proxy/v1/",
+		"custom_headers": map[string]interface{}{
+			"X-Proxy-Token": "secret",
+		},
+	}))
+
+	if diags.HasError() {
+		t.Fatalf("unexpected error configuring provider: %v", diags)
+	}
+
+	r := p.ResourcesMap["statuscake_contact_group"]
+	d := r.TestResourceData()
+	d.SetId("123")
+
+	if diags := r.ReadContext(context.Background(), d, p.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+}
+
+func TestProvider_reservedCustomHeaders(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"Authorization", "user-agent", "Content-Type"} {
+		diags := provider.New("dev")().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+			"custom_headers": map[string]interface{}{
+				name: "value",
+			},
+		}))
+
+		if !diags.HasError() {
+			t.Errorf("expected the %s header to be rejected", name)
+		}
+	}
+
+	diags := provider.New("dev")().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"custom_headers": map[string]interface{}{
+			"X-Proxy-Token": "secret",
+		},
+	}))
+
+	if diags.HasError() {
+		t.Errorf("unexpected error: %v", diags)
+	}
+}
+
+func TestProvider_userAgent(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := "terraform-provider-statuscake/1.2.3 terraform/1.0.0 pipeline/deploy-production"
+
+		if got := r.Header.Get("User-Agent"); got != want {
+			t.Errorf("expected User-Agent %q, got %q", want, got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"id":"123","name":"Operations Team","email_addresses":[],"integrations":[],"mobile_numbers":[]}}`))
+	}))
+	defer server.Close()
+
+	p := provider.New("1.2.3")()
+	p.TerraformVersion = "1.0.0"
+
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"skip_credentials_validation": true,
+		"api_key":                     "my-api-key",
+		"api_url":                     server.URL,
+		"user_agent_suffix":           "pipeline/deploy-production",
+	}))
+
+	if diags.HasError() {
+		t.Fatalf("unexpected error configuring provider: %v", diags)
+	}
+
+	r := p.ResourcesMap["statuscake_contact_group"]
+	d := r.TestResourceData()
+	d.SetId("123")
+
+	if diags := r.ReadContext(context.Background(), d, p.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+}
+
+func TestProvider_credentialsValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status      int
+		body        string
+		skip        bool
+		wantSummary string
+	}{
+		"valid": {
+			status: http.StatusOK,
+			body:   `{"data":[],"metadata":{"page":1,"per_page":1,"page_count":0,"total_count":0}}`,
+		},
+		"rejected": {
+			status:      http.StatusUnauthorized,
+			body:        `{"message":"Unauthorized","errors":{}}`,
+			wantSummary: "Invalid StatusCake API key",
+		},
+		"skipped": {
+			status: http.StatusUnauthorized,
+			body:   `{"message":"Unauthorized","errors":{}}`,
+			skip:   true,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/uptime" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			diags := provider.New("dev")().Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+				"api_key":                     "my-api-key",
+				"api_url":                     server.URL + "/v1",
+				"skip_credentials_validation": tt.skip,
+			}))
+
+			if tt.wantSummary == "" {
+				if diags.HasError() {
+					t.Errorf("unexpected error: %v", diags)
+				}
+
+				return
+			}
+
+			if len(diags) != 1 || diags[0].Summary != tt.wantSummary {
+				t.Fatalf("expected a %q error, got %v", tt.wantSummary, diags)
+			}
+			if !strings.Contains(diags[0].Detail, "HTTP 401") {
+				t.Errorf("expected the status in the detail, got %q", diags[0].Detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package statuscake
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"time"
+)
+
+const (
+	readAfterWriteMinWait = 250 * time.Millisecond
+	readAfterWriteMaxWait = 2 * time.Second
+)
+
+// readAfterWrite reads a resource straight after it was created or updated. The
+// StatusCake API can briefly return a 404 for an object that has just been
+// written, so the read is retried until read_after_write_timeout rather than
+// removing the object from the state.
+//
+// this is only safe because the object is known to exist, and so must not be
+// used when refreshing
+func readAfterWrite(ctx context.Context, d *schema.ResourceData, meta interface{}, read schema.ReadContextFunc) diag.Diagnostics {
+	ctx = context.WithValue(ctx, readAfterWriteKey{}, true)
+	timeout := meta.(*providerMeta).readAfterWriteTimeout
+
+	if timeout <= 0 {
+		return read(ctx, d, meta)
+	}
+
+	id := d.Id()
+	deadline := time.Now().Add(timeout)
+	wait := readAfterWriteMinWait
+
+	for {
+		diags := read(ctx, d, meta)
+
+		// the read clears the id when the object isn't found
+		if d.Id() != "" || diags.HasError() {
+			return diags
+		}
+
+		d.SetId(id)
+
+		if time.Now().Add(wait).After(deadline) {
+			break
+		}
+
+		tflog.SubsystemDebug(ctx, logSubsystemResource, "Object not found after it was written, retrying the read", map[string]interface{}{
+			"wait_ms": wait.Milliseconds(),
+		})
+
+		if err := waitForContext(ctx, wait); err != nil {
+			return errorDiag(err)
+		}
+
+		if wait *= 2; wait > readAfterWriteMaxWait {
+			wait = readAfterWriteMaxWait
+		}
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  "Object not found after it was written",
+			Detail:   fmt.Sprintf("StatusCake still couldn't find object %s within %s after it was created or updated. Try again, or increase read_after_write_timeout.", id, timeout),
+		},
+	}
+}
+
+type readAfterWriteKey struct{}
+
+// isReadAfterWrite determines if a read is straight after a create or update, in
+// which case anything read before the write is out of date
+func isReadAfterWrite(ctx context.Context) bool {
+	v, _ := ctx.Value(readAfterWriteKey{}).(bool)
+
+	return v
+}
@@ -0,0 +1,125 @@
+package statuscake
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// notFoundRead returns a read that doesn't find the object the given number of
+// times, in the same way as the read of a resource
+func notFoundRead(times int32, calls *int32) schema.ReadContextFunc {
+	return func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+		if atomic.AddInt32(calls, 1) <= times {
+			d.SetId("")
+
+			return diag.Diagnostics{{Severity: diag.Warning, Summary: "Uptime test not found"}}
+		}
+
+		return nil
+	}
+}
+
+func TestReadAfterWrite(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		timeout   time.Duration
+		notFound  int32
+		wantCalls int32
+		wantError bool
+		wantID    string
+	}{
+		"found":               {time.Minute, 0, 1, false, "123"},
+		"found after retries": {time.Minute, 2, 3, false, "123"},
+		"never found":         {600 * time.Millisecond, 100, 2, true, "123"},
+		"disabled":            {0, 100, 1, false, ""},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+
+			d := ResourceStatusCakeUptimeTest().TestResourceData()
+			d.SetId("123")
+
+			diags := readAfterWrite(context.Background(), d, &providerMeta{readAfterWriteTimeout: tt.timeout}, notFoundRead(tt.notFound, &calls))
+
+			if diags.HasError() != tt.wantError {
+				t.Errorf("expected error to be %t, got %v", tt.wantError, diags)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("expected %d reads, got %d", tt.wantCalls, calls)
+			}
+			if d.Id() != tt.wantID {
+				t.Errorf("expected the id to be %q, got %q", tt.wantID, d.Id())
+			}
+		})
+	}
+}
+
+func TestReadAfterWrite_stopsWhenContextIsCancelled(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	d := ResourceStatusCakeUptimeTest().TestResourceData()
+	d.SetId("123")
+
+	start := time.Now()
+	diags := readAfterWrite(ctx, d, &providerMeta{readAfterWriteTimeout: time.Minute}, notFoundRead(100, &calls))
+
+	if len(diags) != 1 || diags[0].Summary != "Request to the StatusCake API timed out" {
+		t.Errorf("expected a timeout, got %v", diags)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the retries to stop with the context, took %s", elapsed)
+	}
+}
+
+func TestContactGroupCreate_retriesReadAfterNotFound(t *testing.T) {
+	t.Parallel()
+
+	var gets int32
+
+	client := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"data":{"new_id":"123"}}`))
+		case atomic.AddInt32(&gets, 1) == 1:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"No results found","errors":{}}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":{"id":"123","name":"example","ping_url":"","email_addresses":[],"integrations":[],"mobile_numbers":[]}}`))
+		}
+	})
+
+	r := ResourceStatusCakeContactGroup()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "example"})
+
+	diags := r.CreateContext(context.Background(), d, &providerMeta{client: client, readAfterWriteTimeout: time.Minute})
+
+	if diags.HasError() || len(diags) != 0 {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+	if d.Id() != "123" {
+		t.Errorf("expected the id to be 123, got %q", d.Id())
+	}
+	if gets != 2 {
+		t.Errorf("expected the read to be retried once, got %d reads", gets)
+	}
+}
@@ -2,7 +2,7 @@ package statuscake
 
 import (
 	"context"
-	"github.com/StatusCakeDev/statuscake-go"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -50,16 +50,22 @@ func ResourceStatusCakeContactGroup() *schema.Resource {
 				Description: "List of integration IDs",
 			},
 		},
+		Timeouts: defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
+// contactGroupAPIFields maps the fields of the api to the attributes they're set from
+func contactGroupAPIFields() map[string]string {
+	return apiFieldAttributes(ResourceStatusCakeContactGroup().Schema, nil)
+}
+
 func resourceStatusCakeContactGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*statuscake.APIClient)
+	client := meta.(*providerMeta).client
 
-	req := client.CreateContactGroup(context.TODO()).
+	req := client.CreateContactGroup(ctx).
 		Name(d.Get("name").(string))
 
 	if v, ok := d.GetOk("ping_url"); ok {
@@ -78,34 +84,46 @@ func resourceStatusCakeContactGroupCreate(ctx context.Context, d *schema.Resourc
 	res, err := req.Execute()
 
 	if err != nil {
-		logStatusCakeAPIError(err)
+		logStatusCakeAPIError(ctx, err)
 
-		return apiErrorDiag(err)
+		return apiErrorDiag(err, contactGroupAPIFields())
 	}
 
-	logResponse(res)
+	logResponse(ctx, res)
 
 	d.SetId(res.Data.NewID)
 
-	return resourceStatusCakeContactGroupRead(ctx, d, meta)
+	return readAfterWrite(ctx, d, meta, resourceStatusCakeContactGroupRead)
 }
 
 func resourceStatusCakeContactGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*statuscake.APIClient)
+	client := meta.(*providerMeta).client
 
 	var diags diag.Diagnostics
 
-	res, err := client.GetContactGroup(context.TODO(), d.Id()).Execute()
+	res, err := client.GetContactGroup(ctx, d.Id()).Execute()
 
 	if err != nil {
-		logStatusCakeAPIError(err)
+		logStatusCakeAPIError(ctx, err)
 
 		if !isNotFoundAPIError(err) {
-			return diag.FromErr(err)
+			return apiErrorDiag(err, contactGroupAPIFields())
 		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Contact group not found",
+			Detail:   fmt.Sprintf("Contact group %s no longer exists and has been removed from the state", d.Id()),
+		})
+
+		// the contact group has been deleted outside of terraform, so remove it
+		// from the state to allow it to be recreated
+		d.SetId("")
+
+		return diags
 	}
 
-	logResponse(res)
+	logResponse(ctx, res)
 
 	if err := d.Set("name", res.Data.Name); err != nil {
 		return diag.FromErr(err)
@@ -129,10 +147,10 @@ func resourceStatusCakeContactGroupRead(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceStatusCakeContactGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*statuscake.APIClient)
+	client := meta.(*providerMeta).client
 
 	if d.HasChangesExcept() {
-		req := client.UpdateContactGroup(context.TODO(), d.Id())
+		req := client.UpdateContactGroup(ctx, d.Id())
 
 		if d.HasChange("name") {
 			req = req.Name(d.Get("name").(string))
@@ -151,27 +169,27 @@ func resourceStatusCakeContactGroupUpdate(ctx context.Context, d *schema.Resourc
 		}
 
 		if err := req.Execute(); err != nil {
-			logStatusCakeAPIError(err)
+			logStatusCakeAPIError(ctx, err)
 
-			return apiErrorDiag(err)
+			return apiErrorDiag(err, contactGroupAPIFields())
 		}
 	}
 
-	return resourceStatusCakeContactGroupRead(ctx, d, meta)
+	return readAfterWrite(ctx, d, meta, resourceStatusCakeContactGroupRead)
 }
 
 func resourceStatusCakeContactGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*statuscake.APIClient)
+	client := meta.(*providerMeta).client
 
 	var diags diag.Diagnostics
 
-	err := client.DeleteContactGroup(context.TODO(), d.Id()).Execute()
+	err := client.DeleteContactGroup(ctx, d.Id()).Execute()
 
 	if err != nil {
-		logStatusCakeAPIError(err)
+		logStatusCakeAPIError(ctx, err)
 
 		if !isNotFoundAPIError(err) {
-			return diag.FromErr(err)
+			return apiErrorDiag(err, contactGroupAPIFields())
 		}
 
 		diags = append(diags, diag.Diagnostic{
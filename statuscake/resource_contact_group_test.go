@@ -7,19 +7,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"regexp"
+	provider "terraform-provider-statuscake/statuscake"
 	"testing"
 )
 
 func fetchAllContactGroups() ([]statuscake.ContactGroup, error) {
-	client := statusCakeAPIClient()
-
-	res, err := client.ListContactGroups(context.TODO()).Execute()
+	all, err := provider.ListAllContactGroups(context.TODO(), statusCakeAPIClient())
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch contact groups: %w", err)
 	}
 
-	return res.Data, nil
+	return all, nil
 }
 
 // testAccCheckContactGroupDestroy verifies the contact groups has been destroyed
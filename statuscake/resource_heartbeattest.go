@@ -0,0 +1,227 @@
+package statuscake
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+func ResourceStatusCakeHeartbeatTest() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a StatusCake Heartbeat Test",
+		CreateContext: resourceStatusCakeHeartbeatTestCreate,
+		ReadContext:   resourceStatusCakeHeartbeatTestRead,
+		UpdateContext: resourceStatusCakeHeartbeatTestUpdate,
+		DeleteContext: resourceStatusCakeHeartbeatTestDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the test",
+			},
+			"period": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(30, 172800),
+				Description:  "Number of seconds since the last ping before the test is considered down (between 30 and 172800)",
+			},
+			"contact_groups": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "List of contact group IDs",
+			},
+			"tags": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "List of tags",
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the hosting provider",
+			},
+			"paused": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the test should be run",
+			},
+			"check_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "URL generated by StatusCake that must be pinged to keep the test up",
+			},
+		},
+		Timeouts: defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// heartbeatTestAPIFields maps the fields of the api to the attributes they're set from
+func heartbeatTestAPIFields() map[string]string {
+	return apiFieldAttributes(ResourceStatusCakeHeartbeatTest().Schema, nil)
+}
+
+func resourceStatusCakeHeartbeatTestCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	form := url.Values{}
+
+	form.Set("name", d.Get("name").(string))
+	form.Set("period", strconv.Itoa(d.Get("period").(int)))
+
+	if v, ok := d.GetOk("contact_groups"); ok {
+		form.Set("contact_groups_csv", strings.Join(asListOfStrings(v), ","))
+	}
+	if v, ok := d.GetOk("tags"); ok {
+		form.Set("tags_csv", strings.Join(asListOfStrings(v), ","))
+	}
+	if v, ok := d.GetOk("host"); ok {
+		form.Set("host", v.(string))
+	}
+	if v, ok := d.GetOk("paused"); ok {
+		form.Set("paused", strconv.FormatBool(v.(bool)))
+	}
+
+	res, err := createHeartbeatTest(ctx, client, form)
+
+	if err != nil {
+		logStatusCakeAPIError(ctx, err)
+
+		return apiErrorDiag(err, heartbeatTestAPIFields())
+	}
+
+	logResponse(ctx, res)
+
+	d.SetId(res.Data.NewID)
+
+	return readAfterWrite(ctx, d, meta, resourceStatusCakeHeartbeatTestRead)
+}
+
+func resourceStatusCakeHeartbeatTestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	var diags diag.Diagnostics
+
+	res, err := getHeartbeatTest(ctx, client, d.Id())
+
+	if err != nil {
+		logStatusCakeAPIError(ctx, err)
+
+		if !isNotFoundAPIError(err) {
+			return apiErrorDiag(err, heartbeatTestAPIFields())
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Heartbeat test not found",
+			Detail:   fmt.Sprintf("Heartbeat test %s no longer exists and has been removed from the state", d.Id()),
+		})
+
+		// the heartbeat test has been deleted outside of terraform, so remove it
+		// from the state to allow it to be recreated
+		d.SetId("")
+
+		return diags
+	}
+
+	logResponse(ctx, res)
+
+	if err := d.Set("name", res.Data.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("period", res.Data.Period); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("contact_groups", res.Data.ContactGroups); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", res.Data.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("host", res.Data.Host); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("paused", res.Data.Paused); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("check_url", res.Data.URL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(res.Data.ID)
+
+	return diags
+}
+
+func resourceStatusCakeHeartbeatTestUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	if d.HasChangesExcept() {
+		form := url.Values{}
+
+		if d.HasChange("name") {
+			form.Set("name", d.Get("name").(string))
+		}
+		if d.HasChange("period") {
+			form.Set("period", strconv.Itoa(d.Get("period").(int)))
+		}
+		if d.HasChange("contact_groups") {
+			form.Set("contact_groups_csv", strings.Join(asListOfStrings(d.Get("contact_groups")), ","))
+		}
+		if d.HasChange("tags") {
+			form.Set("tags_csv", strings.Join(asListOfStrings(d.Get("tags")), ","))
+		}
+		if d.HasChange("host") {
+			form.Set("host", d.Get("host").(string))
+		}
+		if d.HasChange("paused") {
+			form.Set("paused", strconv.FormatBool(d.Get("paused").(bool)))
+		}
+
+		if err := updateHeartbeatTest(ctx, client, d.Id(), form); err != nil {
+			logStatusCakeAPIError(ctx, err)
+
+			return apiErrorDiag(err, heartbeatTestAPIFields())
+		}
+	}
+
+	return readAfterWrite(ctx, d, meta, resourceStatusCakeHeartbeatTestRead)
+}
+
+func resourceStatusCakeHeartbeatTestDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	var diags diag.Diagnostics
+
+	err := deleteHeartbeatTest(ctx, client, d.Id())
+
+	if err != nil {
+		logStatusCakeAPIError(ctx, err)
+
+		if !isNotFoundAPIError(err) {
+			return apiErrorDiag(err, heartbeatTestAPIFields())
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Heartbeat test has already been deleted",
+		})
+	}
+
+	return diags
+}
@@ -0,0 +1,171 @@
+package statuscake_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
+	provider "terraform-provider-statuscake/statuscake"
+	"testing"
+)
+
+func fetchAllHeartbeatTests() ([]provider.HeartbeatTest, error) {
+	all, err := provider.ListAllHeartbeatTests(context.TODO(), statusCakeAPIClient())
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch heartbeat tests: %w", err)
+	}
+
+	return all, nil
+}
+
+// testAccCheckHeartbeatTestDestroy verifies the heartbeat test has been destroyed
+func testAccCheckHeartbeatTestDestroy(s *terraform.State) error {
+	// loop through the resources in state, verifying each heartbeat test is destroyed
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "statuscake_heartbeat_test" {
+			continue
+		}
+
+		heartbeatTests, err := fetchAllHeartbeatTests()
+
+		if err == nil {
+			if len(heartbeatTests) > 0 {
+				for _, heartbeatTest := range heartbeatTests {
+					if heartbeatTest.ID == rs.Primary.ID {
+						return fmt.Errorf("heartbeat test (%s) still exists", rs.Primary.ID)
+					}
+				}
+			}
+
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckHeartbeatTestExists(resourceName string) resource.TestCheckFunc { //nolint:unparam
+	return func(s *terraform.State) error {
+		// retrieve the resource by name from state
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("heartbeat test ID is not set")
+		}
+
+		// fetch *all* the heartbeat tests to be sure we're using a unique id
+		heartbeatTests, err := fetchAllHeartbeatTests()
+
+		if err != nil {
+			return err
+		}
+
+		finds := 0
+
+		for _, heartbeatTest := range heartbeatTests {
+			if heartbeatTest.ID == rs.Primary.ID {
+				finds += 1 //nolint:revive
+			}
+		}
+
+		if finds == 0 {
+			return fmt.Errorf("heartbeat test not found")
+		}
+		if finds >= 2 {
+			return fmt.Errorf("multiple heartbeat tests matching id found")
+		}
+
+		return nil
+	}
+}
+
+func TestAccHeartbeatTest_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckHeartbeatTestDestroy,
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "statuscake_heartbeat_test" "foo" {
+						name   = "My Job"
+						period = 1800
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHeartbeatTestExists("statuscake_heartbeat_test.foo"),
+					resource.TestCheckResourceAttrSet("statuscake_heartbeat_test.foo", "check_url"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHeartbeatTest_changing(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckHeartbeatTestDestroy,
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "statuscake_heartbeat_test" "foo" {
+						name   = "My Job"
+						period = 1800
+						host   = "The World"
+						paused = true
+						tags   = ["one", "two"]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHeartbeatTestExists("statuscake_heartbeat_test.foo"),
+				),
+			},
+			{
+				Config: `
+					resource "statuscake_heartbeat_test" "foo" {
+						name   = "My Job!"
+						period = 3600
+						host   = "The Moon"
+						paused = false
+						tags   = ["three"]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHeartbeatTestExists("statuscake_heartbeat_test.foo"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHeartbeatTest_validation(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:        true,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckHeartbeatTestDestroy,
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "statuscake_heartbeat_test" "foo" {
+						name   = "My Job"
+						period = 10
+					}
+				`,
+				ExpectError: regexp.MustCompile("expected period to be in the range"),
+			},
+		},
+	})
+}
@@ -0,0 +1,307 @@
+package statuscake
+
+import (
+	"context"
+	"fmt"
+	"github.com/StatusCakeDev/statuscake-go"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
+
+	// embed the timezone database so that timezones can always be validated,
+	// regardless of what is installed on the machine running terraform
+	_ "time/tzdata"
+)
+
+func ResourceStatusCakeMaintenanceWindow() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a StatusCake Maintenance Window",
+		CreateContext: resourceStatusCakeMaintenanceWindowCreate,
+		ReadContext:   resourceStatusCakeMaintenanceWindowRead,
+		UpdateContext: resourceStatusCakeMaintenanceWindowUpdate,
+		DeleteContext: resourceStatusCakeMaintenanceWindowDelete,
+		CustomizeDiff: resourceStatusCakeMaintenanceWindowCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the maintenance window",
+			},
+			"start_at": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				StateFunc:    normalizeRFC3339Time,
+				Description:  "Start of the maintenance window (RFC3339 format)",
+			},
+			"end_at": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				StateFunc:    normalizeRFC3339Time,
+				Description:  "End of the maintenance window (RFC3339 format)",
+			},
+			"timezone": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateTimezone,
+				Description:      "IANA timezone in which the maintenance window takes place, such as Pacific/Auckland",
+			},
+			"repeat_interval": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Number of days between each occurrence of the maintenance window (one of 0, 1, 7, 14 or 30). A value of 0 means the window never repeats",
+				ValidateFunc: validation.IntInSlice(
+					[]int{
+						0,
+						1,
+						7,
+						14,
+						30,
+					},
+				),
+			},
+			"tests": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:     true,
+				AtLeastOneOf: []string{"tests", "tags"},
+				Description:  "List of uptime test IDs to pause during the maintenance window",
+			},
+			"tags": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:     true,
+				AtLeastOneOf: []string{"tests", "tags"},
+				Description:  "List of tags used to select the uptime tests to pause during the maintenance window",
+			},
+		},
+		Timeouts: defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// maintenanceWindowAPIFields maps the fields of the api to the attributes they're set from
+func maintenanceWindowAPIFields() map[string]string {
+	return apiFieldAttributes(ResourceStatusCakeMaintenanceWindow().Schema, map[string]string{
+		"recur_every": "repeat_interval",
+	})
+}
+
+// normalizeRFC3339Time converts the given RFC3339 time to UTC so that the same
+// moment written with different offsets is considered to be the same value
+func normalizeRFC3339Time(v interface{}) string {
+	t, err := time.Parse(time.RFC3339, v.(string))
+
+	if err != nil {
+		return v.(string)
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func validateTimezone(v interface{}, path cty.Path) diag.Diagnostics {
+	if _, err := time.LoadLocation(v.(string)); err != nil || v.(string) == "" {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid timezone",
+				Detail:        fmt.Sprintf("%q is not a valid IANA timezone, such as Europe/London", v.(string)),
+				AttributePath: path,
+			},
+		}
+	}
+
+	return nil
+}
+
+func resourceStatusCakeMaintenanceWindowCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	// the times might not be known until apply if they're computed from other resources
+	if !d.NewValueKnown("start_at") || !d.NewValueKnown("end_at") {
+		return nil
+	}
+
+	startAt, err := time.Parse(time.RFC3339, d.Get("start_at").(string))
+
+	if err != nil {
+		return nil //nolint:nilerr // this will have been reported by the validation
+	}
+
+	endAt, err := time.Parse(time.RFC3339, d.Get("end_at").(string))
+
+	if err != nil {
+		return nil //nolint:nilerr // this will have been reported by the validation
+	}
+
+	if !endAt.After(startAt) {
+		return fmt.Errorf("end_at (%s) must be after start_at (%s)", d.Get("end_at"), d.Get("start_at"))
+	}
+
+	return nil
+}
+
+func resourceStatusCakeMaintenanceWindowCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// these have already been validated as being RFC3339
+	startAt, _ := time.Parse(time.RFC3339, d.Get("start_at").(string))
+	endAt, _ := time.Parse(time.RFC3339, d.Get("end_at").(string))
+
+	req := client.CreateMaintenanceWindow(ctx).
+		Name(d.Get("name").(string)).
+		StartAt(startAt).
+		EndAt(endAt).
+		Timezone(d.Get("timezone").(string)).
+		RecurEvery(statuscake.MaintenanceWindowRecurrance(d.Get("repeat_interval").(int)))
+
+	if v, ok := d.GetOk("tests"); ok {
+		req = req.Tests(asListOfStrings(v))
+	}
+	if v, ok := d.GetOk("tags"); ok {
+		req = req.Tags(asListOfStrings(v))
+	}
+
+	res, err := req.Execute()
+
+	if err != nil {
+		logStatusCakeAPIError(ctx, err)
+
+		return apiErrorDiag(err, maintenanceWindowAPIFields())
+	}
+
+	logResponse(ctx, res)
+
+	d.SetId(res.Data.NewID)
+
+	return readAfterWrite(ctx, d, meta, resourceStatusCakeMaintenanceWindowRead)
+}
+
+func resourceStatusCakeMaintenanceWindowRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	var diags diag.Diagnostics
+
+	res, err := client.GetMaintenanceWindow(ctx, d.Id()).Execute()
+
+	if err != nil {
+		logStatusCakeAPIError(ctx, err)
+
+		if !isNotFoundAPIError(err) {
+			return apiErrorDiag(err, maintenanceWindowAPIFields())
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Maintenance window not found",
+			Detail:   fmt.Sprintf("Maintenance window %s no longer exists and has been removed from the state", d.Id()),
+		})
+
+		// the maintenance window has been deleted outside of terraform, so remove it
+		// from the state to allow it to be recreated
+		d.SetId("")
+
+		return diags
+	}
+
+	logResponse(ctx, res)
+
+	if err := d.Set("name", res.Data.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("start_at", res.Data.StartAt.UTC().Format(time.RFC3339)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("end_at", res.Data.EndAt.UTC().Format(time.RFC3339)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("timezone", res.Data.Timezone); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("repeat_interval", res.Data.RecurEvery); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tests", res.Data.Tests); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", res.Data.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(res.Data.ID)
+
+	return diags
+}
+
+func resourceStatusCakeMaintenanceWindowUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	if d.HasChangesExcept() {
+		req := client.UpdateMaintenanceWindow(ctx, d.Id())
+
+		if d.HasChange("name") {
+			req = req.Name(d.Get("name").(string))
+		}
+		if d.HasChange("start_at") {
+			startAt, _ := time.Parse(time.RFC3339, d.Get("start_at").(string))
+
+			req = req.StartAt(startAt)
+		}
+		if d.HasChange("end_at") {
+			endAt, _ := time.Parse(time.RFC3339, d.Get("end_at").(string))
+
+			req = req.EndAt(endAt)
+		}
+		if d.HasChange("timezone") {
+			req = req.Timezone(d.Get("timezone").(string))
+		}
+		if d.HasChange("repeat_interval") {
+			req = req.RecurEvery(statuscake.MaintenanceWindowRecurrance(d.Get("repeat_interval").(int)))
+		}
+		if d.HasChange("tests") {
+			req = req.Tests(asListOfStrings(d.Get("tests")))
+		}
+		if d.HasChange("tags") {
+			req = req.Tags(asListOfStrings(d.Get("tags")))
+		}
+
+		if err := req.Execute(); err != nil {
+			logStatusCakeAPIError(ctx, err)
+
+			return apiErrorDiag(err, maintenanceWindowAPIFields())
+		}
+	}
+
+	return readAfterWrite(ctx, d, meta, resourceStatusCakeMaintenanceWindowRead)
+}
+
+func resourceStatusCakeMaintenanceWindowDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	var diags diag.Diagnostics
+
+	err := client.DeleteMaintenanceWindow(ctx, d.Id()).Execute()
+
+	if err != nil {
+		logStatusCakeAPIError(ctx, err)
+
+		if !isNotFoundAPIError(err) {
+			return apiErrorDiag(err, maintenanceWindowAPIFields())
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Maintenance window has already been deleted",
+		})
+	}
+
+	return diags
+}
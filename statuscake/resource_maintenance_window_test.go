@@ -0,0 +1,216 @@
+package statuscake_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/StatusCakeDev/statuscake-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
+	provider "terraform-provider-statuscake/statuscake"
+	"testing"
+)
+
+func fetchAllMaintenanceWindows() ([]statuscake.MaintenanceWindow, error) {
+	all, err := provider.ListAllMaintenanceWindows(context.TODO(), statusCakeAPIClient())
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch maintenance windows: %w", err)
+	}
+
+	return all, nil
+}
+
+// testAccCheckMaintenanceWindowDestroy verifies the maintenance window has been destroyed
+func testAccCheckMaintenanceWindowDestroy(s *terraform.State) error {
+	// loop through the resources in state, verifying each maintenance window is destroyed
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "statuscake_maintenance_window" {
+			continue
+		}
+
+		maintenanceWindows, err := fetchAllMaintenanceWindows()
+
+		if err == nil {
+			if len(maintenanceWindows) > 0 {
+				for _, maintenanceWindow := range maintenanceWindows {
+					if maintenanceWindow.ID == rs.Primary.ID {
+						return fmt.Errorf("maintenance window (%s) still exists", rs.Primary.ID)
+					}
+				}
+			}
+
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckMaintenanceWindowExists(resourceName string) resource.TestCheckFunc { //nolint:unparam
+	return func(s *terraform.State) error {
+		// retrieve the resource by name from state
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("maintenance window ID is not set")
+		}
+
+		// fetch *all* the maintenance windows to be sure we're using a unique id
+		maintenanceWindows, err := fetchAllMaintenanceWindows()
+
+		if err != nil {
+			return err
+		}
+
+		finds := 0
+
+		for _, maintenanceWindow := range maintenanceWindows {
+			if maintenanceWindow.ID == rs.Primary.ID {
+				finds += 1 //nolint:revive
+			}
+		}
+
+		if finds == 0 {
+			return fmt.Errorf("maintenance window not found")
+		}
+		if finds >= 2 {
+			return fmt.Errorf("multiple maintenance windows matching id found")
+		}
+
+		return nil
+	}
+}
+
+func TestAccMaintenanceWindow_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckMaintenanceWindowDestroy,
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "statuscake_maintenance_window" "foo" {
+						name     = "Deploy Night"
+						start_at = "2030-01-01T22:00:00Z"
+						end_at   = "2030-01-01T23:00:00Z"
+						timezone = "UTC"
+						tags     = ["env:production"]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMaintenanceWindowExists("statuscake_maintenance_window.foo"),
+				),
+			},
+			{
+				// the same moments in a different offset shouldn't cause a diff
+				Config: `
+					resource "statuscake_maintenance_window" "foo" {
+						name     = "Deploy Night"
+						start_at = "2030-01-02T11:00:00+13:00"
+						end_at   = "2030-01-02T12:00:00+13:00"
+						timezone = "UTC"
+						tags     = ["env:production"]
+					}
+				`,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccMaintenanceWindow_changing(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckMaintenanceWindowDestroy,
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "statuscake_maintenance_window" "foo" {
+						name            = "Deploy Night"
+						start_at        = "2030-01-01T22:00:00Z"
+						end_at          = "2030-01-01T23:00:00Z"
+						timezone        = "Pacific/Auckland"
+						repeat_interval = 7
+						tags            = ["env:production"]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMaintenanceWindowExists("statuscake_maintenance_window.foo"),
+				),
+			},
+			{
+				Config: `
+					resource "statuscake_maintenance_window" "foo" {
+						name            = "Deploy Night!"
+						start_at        = "2030-02-01T22:00:00Z"
+						end_at          = "2030-02-02T01:00:00Z"
+						timezone        = "Europe/London"
+						repeat_interval = 14
+						tags            = ["env:staging"]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMaintenanceWindowExists("statuscake_maintenance_window.foo"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMaintenanceWindow_validation(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:        true,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckMaintenanceWindowDestroy,
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "statuscake_maintenance_window" "foo" {
+						name     = "Deploy Night"
+						start_at = "2030-01-01T22:00:00Z"
+						end_at   = "2030-01-01T21:00:00Z"
+						timezone = "UTC"
+						tags     = ["env:production"]
+					}
+				`,
+				ExpectError: regexp.MustCompile("must be after start_at"),
+			},
+			{
+				Config: `
+					resource "statuscake_maintenance_window" "foo" {
+						name     = "Deploy Night"
+						start_at = "2030-01-01T22:00:00Z"
+						end_at   = "2030-01-01T23:00:00Z"
+						timezone = "Middle/Earth"
+						tags     = ["env:production"]
+					}
+				`,
+				ExpectError: regexp.MustCompile("Invalid timezone"),
+			},
+			{
+				Config: `
+					resource "statuscake_maintenance_window" "foo" {
+						name     = "Deploy Night"
+						start_at = "tomorrow"
+						end_at   = "2030-01-01T23:00:00Z"
+						timezone = "UTC"
+						tags     = ["env:production"]
+					}
+				`,
+				ExpectError: regexp.MustCompile("expected \"start_at\" to be a valid RFC3339 date"),
+			},
+		},
+	})
+}
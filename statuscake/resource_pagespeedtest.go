@@ -0,0 +1,270 @@
+package statuscake
+
+import (
+	"context"
+	"fmt"
+	"github.com/StatusCakeDev/statuscake-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceStatusCakePagespeedTest() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a StatusCake Pagespeed Test",
+		CreateContext: resourceStatusCakePagespeedTestCreate,
+		ReadContext:   resourceStatusCakePagespeedTestRead,
+		UpdateContext: resourceStatusCakePagespeedTestUpdate,
+		DeleteContext: resourceStatusCakePagespeedTestDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the test",
+			},
+			"website_url": {
+				Type:        schema.TypeString, /* <uri> */
+				Required:    true,
+				ForceNew:    true,
+				Description: "URL, FQDN, or IP address of the website under test",
+			},
+			"check_rate": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Number of minutes between tests",
+				ValidateFunc: validation.IntInSlice(
+					[]int{
+						1,
+						5,
+						10,
+						15,
+						30,
+						60,
+						1440,
+					},
+				),
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Testing location, as an ISO country code (or PRIVATE for private locations)",
+				ValidateFunc: validation.StringInSlice(
+					statuscake.PagespeedTestLocationISOValues(),
+					false,
+				),
+			},
+			"alert_bigger": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "An alert will be sent if the size of the page is larger than this value (kb). A value of 0 prevents alerts being sent",
+			},
+			"alert_slower": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "An alert will be sent if the load time of the page exceeds this value (ms). A value of 0 prevents alerts being sent",
+			},
+			"alert_smaller": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "An alert will be sent if the size of the page is smaller than this value (kb). A value of 0 prevents alerts being sent",
+			},
+			"contact_groups": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "List of contact group IDs",
+			},
+			"paused": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the test should be run",
+			},
+		},
+		Timeouts: defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// pagespeedTestAPIFields maps the fields of the api to the attributes they're set from
+func pagespeedTestAPIFields() map[string]string {
+	return apiFieldAttributes(ResourceStatusCakePagespeedTest().Schema, map[string]string{
+		"location_iso": "region",
+	})
+}
+
+func resourceStatusCakePagespeedTestCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	req := client.CreatePagespeedTest(ctx).
+		Name(d.Get("name").(string)).
+		WebsiteURL(d.Get("website_url").(string)).
+		CheckRate(statuscake.PagespeedTestCheckRate(d.Get("check_rate").(int))).
+		LocationISO(statuscake.PagespeedTestLocationISO(d.Get("region").(string)))
+
+	if v, ok := d.GetOk("alert_bigger"); ok {
+		req = req.AlertBigger(int32(v.(int)))
+	}
+	if v, ok := d.GetOk("alert_slower"); ok {
+		req = req.AlertSlower(int64(v.(int)))
+	}
+	if v, ok := d.GetOk("alert_smaller"); ok {
+		req = req.AlertSmaller(int32(v.(int)))
+	}
+	if v, ok := d.GetOk("contact_groups"); ok {
+		req = req.ContactGroups(asListOfStrings(v))
+	}
+	if v, ok := d.GetOk("paused"); ok {
+		req = req.Paused(v.(bool))
+	}
+
+	res, err := req.Execute()
+
+	if err != nil {
+		logStatusCakeAPIError(ctx, err)
+
+		return apiErrorDiag(err, pagespeedTestAPIFields())
+	}
+
+	logResponse(ctx, res)
+
+	d.SetId(res.Data.NewID)
+
+	return readAfterWrite(ctx, d, meta, resourceStatusCakePagespeedTestRead)
+}
+
+func resourceStatusCakePagespeedTestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	var diags diag.Diagnostics
+
+	res, err := client.GetPagespeedTest(ctx, d.Id()).Execute()
+
+	if err != nil {
+		logStatusCakeAPIError(ctx, err)
+
+		if !isNotFoundAPIError(err) {
+			return apiErrorDiag(err, pagespeedTestAPIFields())
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Pagespeed test not found",
+			Detail:   fmt.Sprintf("Pagespeed test %s no longer exists and has been removed from the state", d.Id()),
+		})
+
+		// the pagespeed test has been deleted outside of terraform, so remove it
+		// from the state to allow it to be recreated
+		d.SetId("")
+
+		return diags
+	}
+
+	logResponse(ctx, res)
+
+	if err := d.Set("name", res.Data.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("website_url", res.Data.WebsiteURL); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("check_rate", res.Data.CheckRate); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("region", res.Data.LocationISO); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("alert_bigger", res.Data.AlertBigger); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("alert_slower", res.Data.AlertSlower); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("alert_smaller", res.Data.AlertSmaller); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("contact_groups", res.Data.ContactGroups); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("paused", res.Data.Paused); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(res.Data.ID)
+
+	return diags
+}
+
+func resourceStatusCakePagespeedTestUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	if d.HasChangesExcept() {
+		req := client.UpdatePagespeedTest(ctx, d.Id())
+
+		if d.HasChange("name") {
+			req = req.Name(d.Get("name").(string))
+		}
+		if d.HasChange("check_rate") {
+			req = req.CheckRate(statuscake.PagespeedTestCheckRate(d.Get("check_rate").(int)))
+		}
+		if d.HasChange("region") {
+			req = req.LocationISO(statuscake.PagespeedTestLocationISO(d.Get("region").(string)))
+		}
+		if d.HasChange("alert_bigger") {
+			req = req.AlertBigger(int32(d.Get("alert_bigger").(int)))
+		}
+		if d.HasChange("alert_slower") {
+			req = req.AlertSlower(int64(d.Get("alert_slower").(int)))
+		}
+		if d.HasChange("alert_smaller") {
+			req = req.AlertSmaller(int32(d.Get("alert_smaller").(int)))
+		}
+		if d.HasChange("contact_groups") {
+			req = req.ContactGroups(asListOfStrings(d.Get("contact_groups")))
+		}
+		if d.HasChange("paused") {
+			req = req.Paused(d.Get("paused").(bool))
+		}
+
+		if err := req.Execute(); err != nil {
+			logStatusCakeAPIError(ctx, err)
+
+			return apiErrorDiag(err, pagespeedTestAPIFields())
+		}
+	}
+
+	return readAfterWrite(ctx, d, meta, resourceStatusCakePagespeedTestRead)
+}
+
+func resourceStatusCakePagespeedTestDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	var diags diag.Diagnostics
+
+	err := client.DeletePagespeedTest(ctx, d.Id()).Execute()
+
+	if err != nil {
+		logStatusCakeAPIError(ctx, err)
+
+		if !isNotFoundAPIError(err) {
+			return apiErrorDiag(err, pagespeedTestAPIFields())
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Pagespeed test has already been deleted",
+		})
+	}
+
+	return diags
+}
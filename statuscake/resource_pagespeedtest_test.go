@@ -0,0 +1,192 @@
+package statuscake_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/StatusCakeDev/statuscake-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
+	provider "terraform-provider-statuscake/statuscake"
+	"testing"
+)
+
+func fetchAllPagespeedTests() ([]statuscake.PagespeedTest, error) {
+	all, err := provider.ListAllPagespeedTests(context.TODO(), statusCakeAPIClient())
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pagespeed tests: %w", err)
+	}
+
+	return all, nil
+}
+
+// testAccCheckPagespeedTestDestroy verifies the pagespeed test has been destroyed
+func testAccCheckPagespeedTestDestroy(s *terraform.State) error {
+	// loop through the resources in state, verifying each pagespeed test is destroyed
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "statuscake_pagespeed_test" {
+			continue
+		}
+
+		pagespeedTests, err := fetchAllPagespeedTests()
+
+		if err == nil {
+			if len(pagespeedTests) > 0 {
+				for _, pagespeedTest := range pagespeedTests {
+					if pagespeedTest.ID == rs.Primary.ID {
+						return fmt.Errorf("pagespeed test (%s) still exists", rs.Primary.ID)
+					}
+				}
+			}
+
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPagespeedTestExists(resourceName string) resource.TestCheckFunc { //nolint:unparam
+	return func(s *terraform.State) error {
+		// retrieve the resource by name from state
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("pagespeed test ID is not set")
+		}
+
+		// fetch *all* the pagespeed tests to be sure we're using a unique id
+		pagespeedTests, err := fetchAllPagespeedTests()
+
+		if err != nil {
+			return err
+		}
+
+		finds := 0
+
+		for _, pagespeedTest := range pagespeedTests {
+			if pagespeedTest.ID == rs.Primary.ID {
+				finds += 1 //nolint:revive
+			}
+		}
+
+		if finds == 0 {
+			return fmt.Errorf("pagespeed test not found")
+		}
+		if finds >= 2 {
+			return fmt.Errorf("multiple pagespeed tests matching id found")
+		}
+
+		return nil
+	}
+}
+
+func TestAccPagespeedTest_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckPagespeedTestDestroy,
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "statuscake_pagespeed_test" "foo" {
+						name        = "My Site"
+						website_url = "https://www.example.com"
+						check_rate  = 60
+						region      = "UK"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPagespeedTestExists("statuscake_pagespeed_test.foo"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPagespeedTest_changing(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckPagespeedTestDestroy,
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "statuscake_pagespeed_test" "foo" {
+						name          = "My Site"
+						website_url   = "https://www.example.com"
+						check_rate    = 60
+						region        = "UK"
+						alert_bigger  = 2000
+						alert_slower  = 5000
+						alert_smaller = 10
+						paused        = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPagespeedTestExists("statuscake_pagespeed_test.foo"),
+				),
+			},
+			{
+				Config: `
+					resource "statuscake_pagespeed_test" "foo" {
+						name          = "My Site!"
+						website_url   = "https://www.example.com"
+						check_rate    = 1440
+						region        = "US"
+						alert_bigger  = 0
+						alert_slower  = 3000
+						alert_smaller = 0
+						paused        = false
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPagespeedTestExists("statuscake_pagespeed_test.foo"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPagespeedTest_validation(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:        true,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckPagespeedTestDestroy,
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "statuscake_pagespeed_test" "foo" {
+						name        = "My Site"
+						website_url = "https://www.example.com"
+						check_rate  = 300
+						region      = "UK"
+					}
+				`,
+				ExpectError: regexp.MustCompile("expected check_rate to be one of"),
+			},
+			{
+				Config: `
+					resource "statuscake_pagespeed_test" "foo" {
+						name        = "My Site"
+						website_url = "https://www.example.com"
+						check_rate  = 60
+						region      = "NZ"
+					}
+				`,
+				ExpectError: regexp.MustCompile("expected region to be one of"),
+			},
+		},
+	})
+}
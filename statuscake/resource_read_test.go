@@ -0,0 +1,93 @@
+package statuscake
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestResourceReadRemovesMissingObjects(t *testing.T) {
+	t.Parallel()
+
+	resources := map[string]*schema.Resource{
+		"statuscake_contact_group":      ResourceStatusCakeContactGroup(),
+		"statuscake_heartbeat_test":     ResourceStatusCakeHeartbeatTest(),
+		"statuscake_maintenance_window": ResourceStatusCakeMaintenanceWindow(),
+		"statuscake_pagespeed_test":     ResourceStatusCakePagespeedTest(),
+		"statuscake_ssl_test":           ResourceStatusCakeSSLTest(),
+		"statuscake_uptime_test":        ResourceStatusCakeUptimeTest(),
+	}
+
+	for name, r := range resources {
+		r := r
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestAPIClient(t, func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message":"No results found","errors":{}}`))
+			})
+
+			d := r.TestResourceData()
+			d.SetId("123")
+
+			diags := r.ReadContext(context.Background(), d, &providerMeta{client: client})
+
+			if d.Id() != "" {
+				t.Errorf("expected the id to be cleared, got %s", d.Id())
+			}
+			if len(diags) != 1 || diags[0].Severity != diag.Warning {
+				t.Errorf("expected a single warning, got %v", diags)
+			}
+		})
+	}
+}
+
+func TestResourceReadStopsWhenContextIsCancelled(t *testing.T) {
+	t.Parallel()
+
+	resources := map[string]*schema.Resource{
+		"statuscake_contact_group":      ResourceStatusCakeContactGroup(),
+		"statuscake_heartbeat_test":     ResourceStatusCakeHeartbeatTest(),
+		"statuscake_maintenance_window": ResourceStatusCakeMaintenanceWindow(),
+		"statuscake_pagespeed_test":     ResourceStatusCakePagespeedTest(),
+		"statuscake_ssl_test":           ResourceStatusCakeSSLTest(),
+		"statuscake_uptime_test":        ResourceStatusCakeUptimeTest(),
+	}
+
+	for name, r := range resources {
+		r := r
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(5 * time.Second):
+				}
+			})
+
+			d := r.TestResourceData()
+			d.SetId("123")
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			diags := r.ReadContext(ctx, d, &providerMeta{client: client})
+
+			if !diags.HasError() {
+				t.Errorf("expected an error, got %v", diags)
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("expected the request to be cancelled, took %s", elapsed)
+			}
+		})
+	}
+}
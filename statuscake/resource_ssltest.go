@@ -0,0 +1,303 @@
+package statuscake
+
+import (
+	"context"
+	"fmt"
+	"github.com/StatusCakeDev/statuscake-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceStatusCakeSSLTest() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a StatusCake SSL Test",
+		CreateContext: resourceStatusCakeSSLTestCreate,
+		ReadContext:   resourceStatusCakeSSLTestRead,
+		UpdateContext: resourceStatusCakeSSLTestUpdate,
+		DeleteContext: resourceStatusCakeSSLTestDelete,
+		Schema: map[string]*schema.Schema{
+			"website_url": {
+				Type:        schema.TypeString, /* <uri> */
+				Required:    true,
+				ForceNew:    true,
+				Description: "URL of the server under test. Must begin with https://",
+			},
+			"check_rate": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Number of seconds between tests",
+				ValidateFunc: validation.IntInSlice(
+					[]int{
+						300,
+						600,
+						1800,
+						3600,
+						86400,
+						2073600,
+					},
+				),
+			},
+			"alert_at": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Required:    true,
+				MinItems:    1,
+				Description: "List of the number of days before the certificate expires at which to send an alert",
+			},
+			"alert_reminder": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to enable alert reminders",
+			},
+			"alert_expiry": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to enable alerts when the SSL certificate is to expire",
+			},
+			"alert_broken": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to enable alerts when SSL certificate issues are found",
+			},
+			"alert_mixed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to enable alerts when mixed content is found",
+			},
+			"contact_groups": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "List of contact group IDs",
+			},
+			"follow_redirects": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to follow redirects when testing. Disabled by default",
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Hostname of the server under test",
+			},
+			"paused": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the test should be run",
+			},
+			"user_agent": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Custom user agent string set when testing",
+			},
+		},
+		Timeouts: defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// sslTestAPIFields maps the fields of the api to the attributes they're set from
+func sslTestAPIFields() map[string]string {
+	return apiFieldAttributes(ResourceStatusCakeSSLTest().Schema, nil)
+}
+
+func resourceStatusCakeSSLTestCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// the api requires the alert flags to always be provided when creating
+	req := client.CreateSslTest(ctx).
+		WebsiteURL(d.Get("website_url").(string)).
+		CheckRate(statuscake.SSLTestCheckRate(d.Get("check_rate").(int))).
+		AlertAt(asListOfIntStrings(d.Get("alert_at"))).
+		AlertReminder(d.Get("alert_reminder").(bool)).
+		AlertExpiry(d.Get("alert_expiry").(bool)).
+		AlertBroken(d.Get("alert_broken").(bool)).
+		AlertMixed(d.Get("alert_mixed").(bool))
+
+	if v, ok := d.GetOk("contact_groups"); ok {
+		req = req.ContactGroups(asListOfStrings(v))
+	}
+	if v, ok := d.GetOk("follow_redirects"); ok {
+		req = req.FollowRedirects(v.(bool))
+	}
+	if v, ok := d.GetOk("hostname"); ok {
+		req = req.Hostname(v.(string))
+	}
+	if v, ok := d.GetOk("paused"); ok {
+		req = req.Paused(v.(bool))
+	}
+	if v, ok := d.GetOk("user_agent"); ok {
+		req = req.UserAgent(v.(string))
+	}
+
+	res, err := req.Execute()
+
+	if err != nil {
+		logStatusCakeAPIError(ctx, err)
+
+		return apiErrorDiag(err, sslTestAPIFields())
+	}
+
+	logResponse(ctx, res)
+
+	d.SetId(res.Data.NewID)
+
+	return readAfterWrite(ctx, d, meta, resourceStatusCakeSSLTestRead)
+}
+
+func resourceStatusCakeSSLTestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	var diags diag.Diagnostics
+
+	res, err := client.GetSslTest(ctx, d.Id()).Execute()
+
+	if err != nil {
+		logStatusCakeAPIError(ctx, err)
+
+		if !isNotFoundAPIError(err) {
+			return apiErrorDiag(err, sslTestAPIFields())
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "SSL test not found",
+			Detail:   fmt.Sprintf("SSL test %s no longer exists and has been removed from the state", d.Id()),
+		})
+
+		// the SSL test has been deleted outside of terraform, so remove it
+		// from the state to allow it to be recreated
+		d.SetId("")
+
+		return diags
+	}
+
+	logResponse(ctx, res)
+
+	if err := d.Set("website_url", res.Data.WebsiteURL); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("check_rate", res.Data.CheckRate); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("alert_at", asListOfInts(res.Data.AlertAt)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("alert_reminder", res.Data.AlertReminder); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("alert_expiry", res.Data.AlertExpiry); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("alert_broken", res.Data.AlertBroken); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("alert_mixed", res.Data.AlertMixed); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("contact_groups", res.Data.ContactGroups); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("follow_redirects", res.Data.FollowRedirects); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("hostname", res.Data.Hostname); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("paused", res.Data.Paused); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("user_agent", res.Data.UserAgent); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(res.Data.ID)
+
+	return diags
+}
+
+func resourceStatusCakeSSLTestUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	if d.HasChangesExcept() {
+		req := client.UpdateSslTest(ctx, d.Id())
+
+		if d.HasChange("check_rate") {
+			req = req.CheckRate(statuscake.SSLTestCheckRate(d.Get("check_rate").(int)))
+		}
+		if d.HasChange("alert_at") {
+			req = req.AlertAt(asListOfIntStrings(d.Get("alert_at")))
+		}
+		if d.HasChange("alert_reminder") {
+			req = req.AlertReminder(d.Get("alert_reminder").(bool))
+		}
+		if d.HasChange("alert_expiry") {
+			req = req.AlertExpiry(d.Get("alert_expiry").(bool))
+		}
+		if d.HasChange("alert_broken") {
+			req = req.AlertBroken(d.Get("alert_broken").(bool))
+		}
+		if d.HasChange("alert_mixed") {
+			req = req.AlertMixed(d.Get("alert_mixed").(bool))
+		}
+		if d.HasChange("contact_groups") {
+			req = req.ContactGroups(asListOfStrings(d.Get("contact_groups")))
+		}
+		if d.HasChange("follow_redirects") {
+			req = req.FollowRedirects(d.Get("follow_redirects").(bool))
+		}
+		if d.HasChange("hostname") {
+			req = req.Hostname(d.Get("hostname").(string))
+		}
+		if d.HasChange("paused") {
+			req = req.Paused(d.Get("paused").(bool))
+		}
+		if d.HasChange("user_agent") {
+			req = req.UserAgent(d.Get("user_agent").(string))
+		}
+
+		if err := req.Execute(); err != nil {
+			logStatusCakeAPIError(ctx, err)
+
+			return apiErrorDiag(err, sslTestAPIFields())
+		}
+	}
+
+	return readAfterWrite(ctx, d, meta, resourceStatusCakeSSLTestRead)
+}
+
+func resourceStatusCakeSSLTestDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	var diags diag.Diagnostics
+
+	err := client.DeleteSslTest(ctx, d.Id()).Execute()
+
+	if err != nil {
+		logStatusCakeAPIError(ctx, err)
+
+		if !isNotFoundAPIError(err) {
+			return apiErrorDiag(err, sslTestAPIFields())
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "SSL test has already been deleted",
+		})
+	}
+
+	return diags
+}
@@ -0,0 +1,185 @@
+package statuscake_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/StatusCakeDev/statuscake-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
+	provider "terraform-provider-statuscake/statuscake"
+	"testing"
+)
+
+func fetchAllSSLTests() ([]statuscake.SSLTest, error) {
+	all, err := provider.ListAllSSLTests(context.TODO(), statusCakeAPIClient())
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ssl tests: %w", err)
+	}
+
+	return all, nil
+}
+
+// testAccCheckSSLTestDestroy verifies the ssl test has been destroyed
+func testAccCheckSSLTestDestroy(s *terraform.State) error {
+	// loop through the resources in state, verifying each ssl test is destroyed
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "statuscake_ssl_test" {
+			continue
+		}
+
+		sslTests, err := fetchAllSSLTests()
+
+		if err == nil {
+			if len(sslTests) > 0 {
+				for _, sslTest := range sslTests {
+					if sslTest.ID == rs.Primary.ID {
+						return fmt.Errorf("ssl test (%s) still exists", rs.Primary.ID)
+					}
+				}
+			}
+
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSSLTestExists(resourceName string) resource.TestCheckFunc { //nolint:unparam
+	return func(s *terraform.State) error {
+		// retrieve the resource by name from state
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("ssl test ID is not set")
+		}
+
+		// fetch *all* the ssl tests to be sure we're using a unique id
+		sslTests, err := fetchAllSSLTests()
+
+		if err != nil {
+			return err
+		}
+
+		finds := 0
+
+		for _, sslTest := range sslTests {
+			if sslTest.ID == rs.Primary.ID {
+				finds += 1 //nolint:revive
+			}
+		}
+
+		if finds == 0 {
+			return fmt.Errorf("ssl test not found")
+		}
+		if finds >= 2 {
+			return fmt.Errorf("multiple ssl tests matching id found")
+		}
+
+		return nil
+	}
+}
+
+func TestAccSSLTest_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckSSLTestDestroy,
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "statuscake_ssl_test" "foo" {
+						website_url = "https://www.example.com"
+						check_rate  = 3600
+						alert_at    = [1, 7, 30]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSSLTestExists("statuscake_ssl_test.foo"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSLTest_changing(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckSSLTestDestroy,
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "statuscake_ssl_test" "foo" {
+						website_url      = "https://www.example.com"
+						check_rate       = 3600
+						alert_at         = [1, 7, 30]
+						alert_reminder   = true
+						alert_expiry     = true
+						alert_broken     = true
+						alert_mixed      = true
+						follow_redirects = true
+						hostname         = "www.example.com"
+						paused           = true
+						user_agent       = "StatusCake"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSSLTestExists("statuscake_ssl_test.foo"),
+				),
+			},
+			{
+				Config: `
+					resource "statuscake_ssl_test" "foo" {
+						website_url      = "https://www.example.com"
+						check_rate       = 86400
+						alert_at         = [3, 14, 60]
+						alert_reminder   = false
+						alert_expiry     = false
+						alert_broken     = false
+						alert_mixed      = false
+						follow_redirects = false
+						hostname         = "example.com"
+						paused           = false
+						user_agent       = "StatusCake2"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSSLTestExists("statuscake_ssl_test.foo"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSLTest_validation(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:        true,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckSSLTestDestroy,
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "statuscake_ssl_test" "foo" {
+						website_url = "https://www.example.com"
+						check_rate  = 60
+						alert_at    = [1, 7, 30]
+					}
+				`,
+				ExpectError: regexp.MustCompile("expected check_rate to be one of"),
+			},
+		},
+	})
+}
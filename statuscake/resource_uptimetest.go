@@ -2,15 +2,19 @@ package statuscake
 
 import (
 	"context"
+	"fmt"
 	"github.com/StatusCakeDev/statuscake-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
 )
 
 func ResourceStatusCakeUptimeTest() *schema.Resource {
 	return &schema.Resource{
 		Description:   "Manages a StatusCake Uptime Test",
+		SchemaVersion: 1,
 		CreateContext: resourceStatusCakeUptimeTestCreate,
 		ReadContext:   resourceStatusCakeUptimeTestRead,
 		UpdateContext: resourceStatusCakeUptimeTestUpdate,
@@ -50,8 +54,9 @@ func ResourceStatusCakeUptimeTest() *schema.Resource {
 			// todo: include valid values in description
 			"check_rate": {
 				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Number of seconds between tests",
+				Optional:    true,
+				Computed:    true,
+				Description: "Number of seconds between tests. Required unless set in the provider `uptime_test_defaults`",
 				ValidateFunc: validation.IntInSlice(
 					[]int{
 						0,
@@ -83,7 +88,7 @@ func ResourceStatusCakeUptimeTest() *schema.Resource {
 			"confirmation": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Default:     2,
+				Computed:    true,
 				Description: "Number of confirmation servers to confirm downtime before an alert is triggered",
 			},
 			"contact_groups": {
@@ -92,12 +97,17 @@ func ResourceStatusCakeUptimeTest() *schema.Resource {
 					Type: schema.TypeString,
 				},
 				Optional:    true,
+				Computed:    true,
 				Description: "List of contact group IDs",
 			},
-			"custom_header": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "JSON object. Represents headers to be sent when making requests",
+			"custom_headers": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:         true,
+				ValidateDiagFunc: validateCustomHeaders,
+				Description:      "Headers to be sent when making requests",
 			},
 			"do_not_find": {
 				Type:        schema.TypeBool,
@@ -147,7 +157,7 @@ func ResourceStatusCakeUptimeTest() *schema.Resource {
 			"include_header": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "Include header content in string match search",
+				Description: "Include header content in string match search. Write-only: the StatusCake API doesn't return it, so it isn't refreshed, changes made outside of Terraform aren't detected and imported tests are always `false`",
 			},
 			"paused": {
 				Type:        schema.TypeBool,
@@ -177,7 +187,18 @@ func ResourceStatusCakeUptimeTest() *schema.Resource {
 					Type: schema.TypeString,
 				},
 				Optional:    true,
-				Description: "List of regions on which to run tests. The values required for this parameter can be retrieved from the GET /v1/uptime-locations endpoint.",
+				Computed:    true,
+				Description: "List of regions on which to run tests. The values required for this parameter can be retrieved from the GET /v1/uptime-locations endpoint. When not set, the regions chosen by StatusCake are used.",
+			},
+			"sensitive_custom_headers": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:         true,
+				Sensitive:        true,
+				ValidateDiagFunc: validateCustomHeaders,
+				Description:      "Headers to be sent when making requests, such as those that carry tokens, whose values are kept out of the plan output. Headers set with `custom_header` before it was removed are moved here",
 			},
 			"status_codes": {
 				Type: schema.TypeList,
@@ -195,16 +216,24 @@ func ResourceStatusCakeUptimeTest() *schema.Resource {
 				Optional:    true,
 				Description: "List of tags",
 			},
+			"tags_all": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed:    true,
+				Description: "All of the tags of the test, including those inherited from the provider `default_tags`",
+			},
 			"timeout": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Default:     40,
+				Computed:    true,
 				Description: "How long to wait to receive the first byte",
 			},
 			"trigger_rate": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Default:     4,
+				Computed:    true,
 				Description: "The number of minutes to wait before sending an alert",
 			},
 			"cookie_storage": {
@@ -216,19 +245,43 @@ func ResourceStatusCakeUptimeTest() *schema.Resource {
 			"user_agent": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				Description: "User agent to be used when making requests",
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffTagsAll,
+			customizeDiffCustomHeaders,
+			customizeDiffUptimeTestDefaults,
+		),
+		Timeouts: defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    uptimeTestSchemaV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeUptimeTestStateV0,
+			},
+		},
 	}
 }
 
+// uptimeTestAPIFields maps the fields of the api to the attributes they're set from
+func uptimeTestAPIFields() map[string]string {
+	return apiFieldAttributes(ResourceStatusCakeUptimeTest().Schema, map[string]string{
+		"custom_header": "custom_headers",
+		"dns_ip":        "dns_ip_csv",
+		"use_jar":       "cookie_storage",
+	})
+}
+
 func resourceStatusCakeUptimeTestCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*statuscake.APIClient)
+	client := meta.(*providerMeta).client
+	defaultTags := meta.(*providerMeta).defaultTags
 
-	req := client.CreateUptimeTest(context.TODO()).
+	req := client.CreateUptimeTest(ctx).
 		Name(d.Get("name").(string)).
 		TestType(statuscake.UptimeTestType(d.Get("test_type").(string))).
 		WebsiteURL(d.Get("website_url").(string)).
@@ -246,13 +299,12 @@ func resourceStatusCakeUptimeTestCreate(ctx context.Context, d *schema.ResourceD
 	if v, ok := d.GetOk("contact_groups"); ok {
 		req = req.ContactGroups(asListOfStrings(v))
 	}
-	if v, ok := d.GetOk("custom_header"); ok {
-		req = req.CustomHeader(v.(string))
-	}
 	if v, ok := d.GetOk("do_not_find"); ok {
 		req = req.DoNotFind(v.(bool))
 	}
-	// todo: 'dns_ip_csv'
+	if v, ok := d.GetOk("dns_ip_csv"); ok {
+		req = req.DNSIP(v.(string))
+	}
 	if v, ok := d.GetOk("dns_server"); ok {
 		req = req.DNSServer(v.(string))
 	}
@@ -271,7 +323,9 @@ func resourceStatusCakeUptimeTestCreate(ctx context.Context, d *schema.ResourceD
 	if v, ok := d.GetOk("host"); ok {
 		req = req.Host(v.(string))
 	}
-	// todo: 'include_header'
+	if v, ok := d.GetOk("include_header"); ok {
+		req = req.IncludeHeader(v.(bool))
+	}
 	if v, ok := d.GetOk("paused"); ok {
 		req = req.Paused(v.(bool))
 	}
@@ -284,12 +338,14 @@ func resourceStatusCakeUptimeTestCreate(ctx context.Context, d *schema.ResourceD
 	if v, ok := d.GetOk("post_raw"); ok {
 		req = req.PostRaw(v.(string))
 	}
-	// todo: 'regions'
+	if v, ok := d.GetOk("regions"); ok {
+		req = req.Regions(asListOfStrings(v))
+	}
 	if v, ok := d.GetOk("status_codes"); ok {
 		req = req.StatusCodes(asListOfStrings(v))
 	}
-	if v, ok := d.GetOk("tags"); ok {
-		req = req.Tags(asListOfStrings(v))
+	if tags := mergeTags(asListOfStrings(d.Get("tags")), defaultTags); len(tags) > 0 {
+		req = req.Tags(tags)
 	}
 	if v, ok := d.GetOk("timeout"); ok {
 		req = req.Timeout(int32(v.(int)))
@@ -304,82 +360,156 @@ func resourceStatusCakeUptimeTestCreate(ctx context.Context, d *schema.ResourceD
 		req = req.UserAgent(v.(string))
 	}
 
+	customHeaders, err := expandCustomHeaders(d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if customHeaders != "" {
+		req = req.CustomHeader(customHeaders)
+	}
+
 	res, err := req.Execute()
 
 	if err != nil {
-		logStatusCakeAPIError(err)
+		logStatusCakeAPIError(ctx, err)
 
-		return apiErrorDiag(err)
+		return apiErrorDiag(err, uptimeTestAPIFields())
 	}
 
-	logResponse(res)
+	logResponse(ctx, res)
 
 	d.SetId(res.Data.NewID)
 
-	// currently, there isn't any way to set status_codes to "nothing" when creating
-	// a new uptime test (meaning it always has a default value of all the codes)
-	// and terraform has no way of providing a default value for a list.
-	//
-	// so we have to do an update straight after creating the uptime test to ensure
-	// that the state matches what terraform expects
-	// todo: discuss with StatusCake if this could be supported somehow?
-	err = client.UpdateUptimeTest(context.TODO(), d.Id()).
-		StatusCodes(asListOfStrings(d.Get("status_codes"))).
-		Execute()
+	// the api defaults status_codes to all of the codes when none are sent while
+	// creating an uptime test, so an empty list can only be set with an update.
+	// when status_codes is set, the test is already correct
+	if _, ok := d.GetOk("status_codes"); !ok {
+		err = client.UpdateUptimeTest(ctx, d.Id()).
+			StatusCodes([]string{}).
+			Execute()
 
-	if err != nil {
-		logStatusCakeAPIError(err)
+		if err != nil {
+			logStatusCakeAPIError(ctx, err)
+
+			diags := apiErrorDiag(err, uptimeTestAPIFields())
+
+			return append(diags, rollbackUptimeTestCreate(ctx, d, client)...)
+		}
+	}
+
+	return readAfterWrite(ctx, d, meta, resourceStatusCakeUptimeTestRead)
+}
 
-		return apiErrorDiag(err)
+// rollbackUptimeTestCreate deletes an uptime test that was created but couldn't
+// be finished, so that a failed create doesn't leave a test behind that would be
+// tainted and then replaced by the next apply
+func rollbackUptimeTestCreate(ctx context.Context, d *schema.ResourceData, client *statuscake.APIClient) diag.Diagnostics {
+	// the create may have failed because its context was cancelled, which must
+	// not stop the test from being deleted
+	ctx, cancel := context.WithTimeout(detachedContext{ctx}, time.Minute)
+	defer cancel()
+
+	id := d.Id()
+
+	if err := client.DeleteUptimeTest(ctx, id).Execute(); err != nil && !isNotFoundAPIError(err) {
+		logStatusCakeAPIError(ctx, err)
+
+		// keep the id so the test is tainted, and deleted by the next apply, rather
+		// than left behind without terraform knowing about it
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  "Could not delete the partially created uptime test",
+				Detail:   fmt.Sprintf("Uptime test %s was created but couldn't be finished, and then couldn't be deleted: %s. It has been kept in the state and will be replaced by the next apply.", id, err),
+			},
+		}
 	}
 
-	logResponse(res)
+	d.SetId("")
 
-	return resourceStatusCakeUptimeTestRead(ctx, d, meta)
+	return nil
 }
 
 func resourceStatusCakeUptimeTestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*statuscake.APIClient)
+	client := meta.(*providerMeta).client
+	defaultTags := meta.(*providerMeta).defaultTags
 
 	var diags diag.Diagnostics
 
-	res, err := client.GetUptimeTest(context.TODO(), d.Id()).Execute()
+	// the uptime test has been deleted outside of terraform, so remove it from the
+	// state to allow it to be recreated
+	notFound := func() diag.Diagnostics {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Uptime test not found",
+			Detail:   fmt.Sprintf("Uptime test %s no longer exists and has been removed from the state", d.Id()),
+		})
+
+		d.SetId("")
+
+		return diags
+	}
+
+	// the list of all uptime tests finds tests that have been deleted without a
+	// request for each of them, though the rest of the attributes of a test that
+	// still exists aren't in its overview, so it's still got in full
+	overview, found, cached := meta.(*providerMeta).uptimeTests.lookup(ctx, client, d.Id())
+
+	if cached && !found {
+		return notFound()
+	}
+
+	res, err := client.GetUptimeTest(ctx, d.Id()).Execute()
 
 	if err != nil {
-		logStatusCakeAPIError(err)
+		logStatusCakeAPIError(ctx, err)
 
 		if !isNotFoundAPIError(err) {
-			return diag.FromErr(err)
+			return apiErrorDiag(err, uptimeTestAPIFields())
 		}
+
+		return notFound()
 	}
 
-	logResponse(res)
+	logResponse(ctx, res)
 
-	if err := d.Set("name", res.Data.Name); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("test_type", res.Data.TestType); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("website_url", res.Data.WebsiteURL); err != nil {
-		return diag.FromErr(err)
+	if !cached {
+		overview = statuscake.UptimeTestOverview{
+			Paused:        res.Data.Paused,
+			Name:          res.Data.Name,
+			WebsiteURL:    res.Data.WebsiteURL,
+			TestType:      res.Data.TestType,
+			CheckRate:     res.Data.CheckRate,
+			ContactGroups: res.Data.ContactGroups,
+			Tags:          res.Data.Tags,
+		}
 	}
-	if err := d.Set("check_rate", res.Data.CheckRate); err != nil {
+
+	if err := setUptimeTestOverview(d, overview, defaultTags); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("confirmation", res.Data.Confirmation); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("contact_groups", res.Data.ContactGroups); err != nil {
+	if headers, err := parseCustomHeaders(res.Data.CustomHeader); err != nil {
+		// keep the headers in the state rather than failing every refresh, as they
+		// can be set to anything outside of terraform
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Could not read the custom headers of the uptime test",
+			Detail:   fmt.Sprintf("The custom headers of uptime test %s have been left unchanged: %s", d.Id(), err),
+		})
+	} else if err := setCustomHeaders(d, headers); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("custom_header", res.Data.CustomHeader); err != nil {
+	if err := d.Set("do_not_find", res.Data.DoNotFind); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("do_not_find", res.Data.DoNotFind); err != nil {
+	if err := d.Set("dns_ip_csv", res.Data.DNSIP); err != nil {
 		return diag.FromErr(err)
 	}
-	// todo: 'dns_ip_csv'
 	if err := d.Set("dns_server", res.Data.DNSServer); err != nil {
 		return diag.FromErr(err)
 	}
@@ -398,10 +528,7 @@ func resourceStatusCakeUptimeTestRead(ctx context.Context, d *schema.ResourceDat
 	if err := d.Set("host", res.Data.Host); err != nil {
 		return diag.FromErr(err)
 	}
-	// todo: 'include_header'
-	if err := d.Set("paused", res.Data.Paused); err != nil {
-		return diag.FromErr(err)
-	}
+	// the api doesn't currently return 'include_header', so we keep whatever is in state
 	if err := d.Set("port", res.Data.Port); err != nil {
 		return diag.FromErr(err)
 	}
@@ -411,11 +538,10 @@ func resourceStatusCakeUptimeTestRead(ctx context.Context, d *schema.ResourceDat
 	if err := d.Set("post_raw", res.Data.PostRaw); err != nil {
 		return diag.FromErr(err)
 	}
-	// todo: 'regions'
-	if err := d.Set("status_codes", res.Data.StatusCodes); err != nil {
+	if err := setUptimeTestRegions(d, res.Data.Servers); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("tags", res.Data.Tags); err != nil {
+	if err := d.Set("status_codes", res.Data.StatusCodes); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("timeout", res.Data.Timeout); err != nil {
@@ -436,11 +562,76 @@ func resourceStatusCakeUptimeTestRead(ctx context.Context, d *schema.ResourceDat
 	return diags
 }
 
+// setUptimeTestOverview stores the attributes of an uptime test that are in both
+// the test itself and the overview of it in the list of all tests
+func setUptimeTestOverview(d *schema.ResourceData, test statuscake.UptimeTestOverview, defaultTags []string) error {
+	if err := d.Set("name", test.Name); err != nil {
+		return err
+	}
+	if err := d.Set("test_type", test.TestType); err != nil {
+		return err
+	}
+	if err := d.Set("website_url", test.WebsiteURL); err != nil {
+		return err
+	}
+	if err := d.Set("check_rate", test.CheckRate); err != nil {
+		return err
+	}
+	if err := d.Set("contact_groups", test.ContactGroups); err != nil {
+		return err
+	}
+	if err := d.Set("paused", test.Paused); err != nil {
+		return err
+	}
+	if err := d.Set("tags", resourceTags(test.Tags, asListOfStrings(d.Get("tags")), defaultTags)); err != nil {
+		return err
+	}
+	if err := d.Set("tags_all", test.Tags); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// setUptimeTestRegions stores the unique regions of the servers the test runs on,
+// keeping the existing order if the regions themselves haven't changed since the
+// api doesn't return them in the order they were given
+func setUptimeTestRegions(d *schema.ResourceData, servers []statuscake.MonitoringLocation) error {
+	regions := make([]string, 0, len(servers))
+	seen := make(map[string]bool, len(servers))
+
+	for _, server := range servers {
+		if server.RegionCode == "" || seen[server.RegionCode] {
+			continue
+		}
+
+		seen[server.RegionCode] = true
+		regions = append(regions, server.RegionCode)
+	}
+
+	current := make(map[string]bool)
+
+	for _, region := range asListOfStrings(d.Get("regions")) {
+		if !seen[region] {
+			return d.Set("regions", regions)
+		}
+
+		current[region] = true
+	}
+
+	if len(current) == len(regions) {
+		return nil
+	}
+
+	return d.Set("regions", regions)
+}
+
 func resourceStatusCakeUptimeTestUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*statuscake.APIClient)
+	client := meta.(*providerMeta).client
+	defaultTags := meta.(*providerMeta).defaultTags
 
 	if d.HasChangesExcept() {
-		req := client.UpdateUptimeTest(context.TODO(), d.Id())
+		req := client.UpdateUptimeTest(ctx, d.Id())
 
 		if d.HasChange("name") {
 			req = req.Name(d.Get("name").(string))
@@ -460,13 +651,21 @@ func resourceStatusCakeUptimeTestUpdate(ctx context.Context, d *schema.ResourceD
 		if d.HasChange("contact_groups") {
 			req = req.ContactGroups(asListOfStrings(d.Get("contact_groups")))
 		}
-		if d.HasChange("custom_header") {
-			req = req.CustomHeader(d.Get("custom_header").(string))
+		if d.HasChanges("custom_headers", "sensitive_custom_headers") {
+			customHeaders, err := expandCustomHeaders(d)
+
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			req = req.CustomHeader(customHeaders)
 		}
 		if d.HasChange("do_not_find") {
 			req = req.DoNotFind(d.Get("do_not_find").(bool))
 		}
-		// todo: 'dns_ip_csv'
+		if d.HasChange("dns_ip_csv") {
+			req = req.DNSIP(d.Get("dns_ip_csv").(string))
+		}
 		if d.HasChange("dns_server") {
 			req = req.DNSServer(d.Get("dns_server").(string))
 		}
@@ -485,7 +684,9 @@ func resourceStatusCakeUptimeTestUpdate(ctx context.Context, d *schema.ResourceD
 		if d.HasChange("host") {
 			req = req.Host(d.Get("host").(string))
 		}
-		// todo: 'include_header'
+		if d.HasChange("include_header") {
+			req = req.IncludeHeader(d.Get("include_header").(bool))
+		}
 		if d.HasChange("paused") {
 			req = req.Paused(d.Get("paused").(bool))
 		}
@@ -498,12 +699,14 @@ func resourceStatusCakeUptimeTestUpdate(ctx context.Context, d *schema.ResourceD
 		if d.HasChange("post_raw") {
 			req = req.PostRaw(d.Get("post_raw").(string))
 		}
-		// todo: 'regions'
+		if d.HasChange("regions") {
+			req = req.Regions(asListOfStrings(d.Get("regions")))
+		}
 		if d.HasChange("status_codes") {
 			req = req.StatusCodes(asListOfStrings(d.Get("status_codes")))
 		}
-		if d.HasChange("tags") {
-			req = req.Tags(asListOfStrings(d.Get("tags")))
+		if d.HasChanges("tags", "tags_all") {
+			req = req.Tags(mergeTags(asListOfStrings(d.Get("tags")), defaultTags))
 		}
 		if d.HasChange("timeout") {
 			req = req.Timeout(int32(d.Get("timeout").(int)))
@@ -519,27 +722,27 @@ func resourceStatusCakeUptimeTestUpdate(ctx context.Context, d *schema.ResourceD
 		}
 
 		if err := req.Execute(); err != nil {
-			logStatusCakeAPIError(err)
+			logStatusCakeAPIError(ctx, err)
 
-			return apiErrorDiag(err)
+			return apiErrorDiag(err, uptimeTestAPIFields())
 		}
 	}
 
-	return resourceStatusCakeUptimeTestRead(ctx, d, meta)
+	return readAfterWrite(ctx, d, meta, resourceStatusCakeUptimeTestRead)
 }
 
 func resourceStatusCakeUptimeTestDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*statuscake.APIClient)
+	client := meta.(*providerMeta).client
 
 	var diags diag.Diagnostics
 
-	err := client.DeleteUptimeTest(context.TODO(), d.Id()).Execute()
+	err := client.DeleteUptimeTest(ctx, d.Id()).Execute()
 
 	if err != nil {
-		logStatusCakeAPIError(err)
+		logStatusCakeAPIError(ctx, err)
 
 		if !isNotFoundAPIError(err) {
-			return diag.FromErr(err)
+			return apiErrorDiag(err, uptimeTestAPIFields())
 		}
 
 		diags = append(diags, diag.Diagnostic{
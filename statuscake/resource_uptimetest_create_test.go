@@ -0,0 +1,202 @@
+package statuscake
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// uptimeTestAPI is a fake of the uptime test endpoints that records the requests
+// it receives, and fails updates & deletes with the given statuses
+type uptimeTestAPI struct {
+	mu       sync.Mutex
+	requests []string
+	forms    map[string]string
+
+	updateStatus int
+	deleteStatus int
+
+	// onUpdate is called before responding to an update
+	onUpdate func()
+}
+
+func (a *uptimeTestAPI) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("could not parse form: %s", err)
+		}
+
+		a.mu.Lock()
+		a.requests = append(a.requests, r.Method+" "+r.URL.Path)
+		a.forms[r.Method] = r.PostForm.Encode()
+		a.mu.Unlock()
+
+		if r.Method == http.MethodPut && a.onUpdate != nil {
+			a.onUpdate()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"data":{"new_id":"123"}}`))
+		case r.Method == http.MethodPut && a.updateStatus != 0:
+			w.WriteHeader(a.updateStatus)
+			_, _ = w.Write([]byte(`{"message":"The provided parameters are invalid","errors":{"status_codes_csv":["Status codes are invalid"]}}`))
+		case r.Method == http.MethodDelete && a.deleteStatus != 0:
+			w.WriteHeader(a.deleteStatus)
+			_, _ = w.Write([]byte(`{"message":"Something went wrong","errors":{}}`))
+		case r.Method == http.MethodPut || r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_, _ = w.Write([]byte(`{"data":{"id":"123","name":"example","test_type":"HTTP","website_url":"https://www.example.com","check_rate":300,"status_codes":["200"]}}`))
+		}
+	}
+}
+
+func (a *uptimeTestAPI) sent(request string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, r := range a.requests {
+		if r == request {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (a *uptimeTestAPI) form(method string) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.forms[method]
+}
+
+func newUptimeTestResourceData(t *testing.T, statusCodes []interface{}) *schema.ResourceData {
+	t.Helper()
+
+	return schema.TestResourceDataRaw(t, ResourceStatusCakeUptimeTest().Schema, map[string]interface{}{
+		"name":         "example",
+		"test_type":    "HTTP",
+		"website_url":  "https://www.example.com",
+		"check_rate":   300,
+		"status_codes": statusCodes,
+	})
+}
+
+func TestUptimeTestCreate_withStatusCodes(t *testing.T) {
+	t.Parallel()
+
+	api := &uptimeTestAPI{forms: map[string]string{}}
+	client := newTestAPIClient(t, api.handler(t))
+
+	d := newUptimeTestResourceData(t, []interface{}{"200"})
+
+	if diags := resourceStatusCakeUptimeTestCreate(context.Background(), d, &providerMeta{client: client}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "123" {
+		t.Errorf("expected the id to be 123, got %s", d.Id())
+	}
+	if !strings.Contains(api.form(http.MethodPost), "status_codes_csv=200") {
+		t.Errorf("expected the status codes to be sent when creating the test, got %q", api.form(http.MethodPost))
+	}
+	if api.sent("PUT /v1/uptime/123") {
+		t.Errorf("expected the test not to be updated, got %v", api.requests)
+	}
+}
+
+func TestUptimeTestCreate_withoutStatusCodes(t *testing.T) {
+	t.Parallel()
+
+	api := &uptimeTestAPI{forms: map[string]string{}}
+	client := newTestAPIClient(t, api.handler(t))
+
+	d := newUptimeTestResourceData(t, []interface{}{})
+
+	if diags := resourceStatusCakeUptimeTestCreate(context.Background(), d, &providerMeta{client: client}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "123" {
+		t.Errorf("expected the id to be 123, got %s", d.Id())
+	}
+	if got := api.form(http.MethodPut); got != "status_codes_csv=" {
+		t.Errorf("expected the status codes to be cleared, got %q", got)
+	}
+}
+
+func TestUptimeTestCreate_rollsBackWhenUpdateFails(t *testing.T) {
+	t.Parallel()
+
+	api := &uptimeTestAPI{forms: map[string]string{}, updateStatus: http.StatusBadRequest}
+	client := newTestAPIClient(t, api.handler(t))
+
+	d := newUptimeTestResourceData(t, []interface{}{})
+
+	diags := resourceStatusCakeUptimeTestCreate(context.Background(), d, &providerMeta{client: client})
+
+	if len(diags) != 1 || diags[0].Summary != "Status codes are invalid" {
+		t.Errorf("expected the update error, got %v", diags)
+	}
+	if !api.sent("DELETE /v1/uptime/123") {
+		t.Errorf("expected the uptime test to be deleted, got %v", api.requests)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the id to be cleared, got %s", d.Id())
+	}
+}
+
+func TestUptimeTestCreate_rollbackFails(t *testing.T) {
+	t.Parallel()
+
+	api := &uptimeTestAPI{forms: map[string]string{}, updateStatus: http.StatusBadRequest, deleteStatus: http.StatusInternalServerError}
+	client := newTestAPIClient(t, api.handler(t))
+
+	d := newUptimeTestResourceData(t, []interface{}{})
+
+	diags := resourceStatusCakeUptimeTestCreate(context.Background(), d, &providerMeta{client: client})
+
+	if len(diags) != 2 || diags[1].Summary != "Could not delete the partially created uptime test" {
+		t.Errorf("expected the update and delete errors, got %v", diags)
+	}
+
+	// the test still exists, so it has to stay in the state to be replaced
+	if d.Id() != "123" {
+		t.Errorf("expected the id to be kept, got %q", d.Id())
+	}
+}
+
+func TestUptimeTestCreate_rollsBackWhenCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// cancel the create while the status codes are being updated
+	api := &uptimeTestAPI{forms: map[string]string{}, onUpdate: func() {
+		cancel()
+		time.Sleep(50 * time.Millisecond)
+	}}
+	client := newTestAPIClient(t, api.handler(t))
+
+	d := newUptimeTestResourceData(t, []interface{}{})
+
+	if diags := resourceStatusCakeUptimeTestCreate(ctx, d, &providerMeta{client: client}); !diags.HasError() {
+		t.Error("expected an error")
+	}
+	if !api.sent("DELETE /v1/uptime/123") {
+		t.Errorf("expected the uptime test to be deleted, got %v", api.requests)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the id to be cleared, got %s", d.Id())
+	}
+}
@@ -0,0 +1,54 @@
+package statuscake
+
+import (
+	"github.com/StatusCakeDev/statuscake-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"reflect"
+	"testing"
+)
+
+func TestSetUptimeTestRegions(t *testing.T) {
+	t.Parallel()
+
+	servers := []statuscake.MonitoringLocation{
+		{RegionCode: "london"},
+		{RegionCode: "sydney"},
+		{RegionCode: "london"},
+	}
+
+	tests := []struct {
+		name    string
+		current []interface{}
+		want    []string
+	}{
+		{"unset", nil, []string{"london", "sydney"}},
+		{"same order", []interface{}{"london", "sydney"}, []string{"london", "sydney"}},
+		{"different order", []interface{}{"sydney", "london"}, []string{"sydney", "london"}},
+		{"changed", []interface{}{"london", "tokyo"}, []string{"london", "sydney"}},
+		{"fewer", []interface{}{"london"}, []string{"london", "sydney"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := map[string]interface{}{}
+
+			if tt.current != nil {
+				raw["regions"] = tt.current
+			}
+
+			d := schema.TestResourceDataRaw(t, ResourceStatusCakeUptimeTest().Schema, raw)
+
+			if err := setUptimeTestRegions(d, servers); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := asListOfStrings(d.Get("regions")); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected regions to be %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -7,32 +7,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"regexp"
+	provider "terraform-provider-statuscake/statuscake"
 	"testing"
 )
 
 func fetchAllUptimeTests() ([]statuscake.UptimeTestOverview, error) {
-	var uptimeTests []statuscake.UptimeTestOverview
+	all, err := provider.ListAllUptimeTests(context.TODO(), statusCakeAPIClient())
 
-	client := statusCakeAPIClient()
-	currentPage := int32(1)
-
-	for {
-		res, err := client.ListUptimeTests(context.TODO()).
-			Page(currentPage).
-			Execute()
-
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch uptime tests: %w", err)
-		}
-
-		uptimeTests = append(uptimeTests, res.Data...)
-
-		currentPage += 1 //nolint:revive
-
-		if currentPage >= *res.Metadata.PageCount {
-			return uptimeTests, nil
-		}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch uptime tests: %w", err)
 	}
+
+	return all, nil
 }
 
 // testAccCheckUptimeTestDestroy verifies the uptime test has been destroyed
@@ -140,7 +126,7 @@ func TestAccUptimeTest_changing(t *testing.T) {
 						test_type        = "HTTP"
 						check_rate       = 300
 						confirmation     = 3
-					  custom_header    = ""
+						custom_headers   = { "X-Test" = "one" }
 						do_not_find      = true
 						dns_server       = "my-host"
 						enable_ssl_alert = true
@@ -148,6 +134,7 @@ func TestAccUptimeTest_changing(t *testing.T) {
 						find_string      = "example"
 						follow_redirects = true
 						host             = "The World"
+						include_header   = true
 						paused           = true
 						port             = 443
 						post_body        = "{}"
@@ -172,7 +159,7 @@ func TestAccUptimeTest_changing(t *testing.T) {
 						test_type        = "HTTP"
 						check_rate       = 900
 						confirmation     = 1
-					  custom_header    = ""
+						custom_headers   = { "X-Test" = "two" }
 						do_not_find      = false
 						dns_server       = "my-other-host"
 						enable_ssl_alert = false
@@ -180,6 +167,7 @@ func TestAccUptimeTest_changing(t *testing.T) {
 						find_string      = "no-thanks"
 						follow_redirects = false
 						host             = "The Moon"
+						include_header   = false
 						paused           = false
 						port             = 80
 						post_body        = ""
@@ -194,6 +182,76 @@ func TestAccUptimeTest_changing(t *testing.T) {
 				`,
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckUptimeTestExists("statuscake_uptime_test.foo"),
+					resource.TestCheckResourceAttr("statuscake_uptime_test.foo", "custom_headers.X-Test", "two"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUptimeTest_dns(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckUptimeTestDestroy,
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "statuscake_uptime_test" "foo" {
+						name        = "My DNS"
+						website_url = "www.example.com"
+						test_type   = "DNS"
+						check_rate  = 300
+						dns_server  = "8.8.8.8"
+						dns_ip_csv  = "93.184.216.34"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUptimeTestExists("statuscake_uptime_test.foo"),
+					resource.TestCheckResourceAttr("statuscake_uptime_test.foo", "dns_ip_csv", "93.184.216.34"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUptimeTest_regions(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckUptimeTestDestroy,
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "statuscake_uptime_test" "foo" {
+						name        = "My Site"
+						website_url = "https://www.example.com"
+						test_type   = "HTTP"
+						check_rate  = 300
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUptimeTestExists("statuscake_uptime_test.foo"),
+					resource.TestCheckResourceAttrSet("statuscake_uptime_test.foo", "regions.0"),
+				),
+			},
+			{
+				Config: `
+					resource "statuscake_uptime_test" "foo" {
+						name        = "My Site"
+						website_url = "https://www.example.com"
+						test_type   = "HTTP"
+						check_rate  = 300
+						regions     = ["london", "sydney"]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUptimeTestExists("statuscake_uptime_test.foo"),
+					resource.TestCheckResourceAttr("statuscake_uptime_test.foo", "regions.#", "2"),
 				),
 			},
 		},
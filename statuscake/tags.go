@@ -0,0 +1,98 @@
+package statuscake
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// mergeTags returns the tags of a resource followed by any of the default tags
+// that it doesn't already have
+func mergeTags(tags, defaultTags []string) []string {
+	merged := make([]string, 0, len(tags)+len(defaultTags))
+	seen := make(map[string]bool, len(tags)+len(defaultTags))
+
+	for _, list := range [][]string{tags, defaultTags} {
+		for _, tag := range list {
+			if seen[tag] {
+				continue
+			}
+
+			seen[tag] = true
+			merged = append(merged, tag)
+		}
+	}
+
+	return merged
+}
+
+// resourceTags returns the tags from the api that belong to the resource itself,
+// which is every tag except for the default tags that the resource doesn't
+// explicitly have in its configuration
+func resourceTags(apiTags, currentTags, defaultTags []string) []string {
+	current := make(map[string]bool, len(currentTags))
+
+	for _, tag := range currentTags {
+		current[tag] = true
+	}
+
+	defaults := make(map[string]bool, len(defaultTags))
+
+	for _, tag := range defaultTags {
+		defaults[tag] = true
+	}
+
+	tags := make([]string, 0, len(apiTags))
+
+	for _, tag := range apiTags {
+		if defaults[tag] && !current[tag] {
+			continue
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
+
+// customizeDiffTagsAll plans "tags_all" as the tags of the resource merged with
+// the default tags, so that changing the default tags updates the resource
+func customizeDiffTagsAll(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	var defaultTags []string
+
+	if m, ok := meta.(*providerMeta); ok {
+		defaultTags = m.defaultTags
+	}
+
+	all := mergeTags(asListOfStrings(d.Get("tags")), defaultTags)
+
+	if sameTags(asListOfStrings(d.Get("tags_all").(*schema.Set).List()), all) {
+		return nil
+	}
+
+	return d.SetNew("tags_all", all)
+}
+
+// sameTags determines if the two lists have the same tags, ignoring order
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	tags := make(map[string]bool, len(a))
+
+	for _, tag := range a {
+		tags[tag] = true
+	}
+
+	for _, tag := range b {
+		if !tags[tag] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,137 @@
+package statuscake
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		tags        []string
+		defaultTags []string
+		want        []string
+	}{
+		{"no defaults", []string{"web"}, nil, []string{"web"}},
+		{"only defaults", nil, []string{"team:ops"}, []string{"team:ops"}},
+		{"both", []string{"web"}, []string{"team:ops", "env:prod"}, []string{"web", "team:ops", "env:prod"}},
+		{"overlapping", []string{"web", "team:ops"}, []string{"team:ops"}, []string{"web", "team:ops"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := mergeTags(tt.tags, tt.defaultTags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestResourceTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		apiTags     []string
+		currentTags []string
+		defaultTags []string
+		want        []string
+	}{
+		{"no defaults", []string{"web", "api"}, []string{"web", "api"}, nil, []string{"web", "api"}},
+		{"removes defaults", []string{"web", "team:ops"}, []string{"web"}, []string{"team:ops"}, []string{"web"}},
+		{"keeps configured defaults", []string{"web", "team:ops"}, []string{"web", "team:ops"}, []string{"team:ops"}, []string{"web", "team:ops"}},
+		{"keeps tags added outside of terraform", []string{"web", "manual"}, []string{"web"}, []string{"team:ops"}, []string{"web", "manual"}},
+		{"import", []string{"web", "team:ops"}, nil, []string{"team:ops"}, []string{"web"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := resourceTags(tt.apiTags, tt.currentTags, tt.defaultTags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUptimeTestDefaultTagsDiff(t *testing.T) {
+	t.Parallel()
+
+	r := ResourceStatusCakeUptimeTest()
+	meta := &providerMeta{defaultTags: []string{"team:ops"}}
+
+	state := r.TestResourceData()
+	state.SetId("123")
+
+	for k, v := range map[string]interface{}{
+		"name":        "My Site",
+		"website_url": "https://example.com",
+		"test_type":   "HTTP",
+		"check_rate":  300,
+		"tags":        []interface{}{"web"},
+		"tags_all":    []interface{}{"web", "team:ops"},
+	} {
+		if err := state.Set(k, v); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	config := map[string]interface{}{
+		"name":        "My Site",
+		"website_url": "https://example.com",
+		"test_type":   "HTTP",
+		"check_rate":  300,
+		"tags":        []interface{}{"web"},
+	}
+
+	diff, err := r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(config), meta)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for k := range diff.Attributes {
+		if strings.HasPrefix(k, "tags") {
+			t.Errorf("expected the default tags to not cause a diff, got %s", k)
+		}
+	}
+
+	meta.defaultTags = []string{"team:sre"}
+
+	diff, err = r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(config), meta)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff == nil {
+		t.Fatal("expected changing the default tags to cause a diff")
+	}
+
+	changed := false
+
+	for k := range diff.Attributes {
+		if strings.HasPrefix(k, "tags.") {
+			t.Errorf("expected tags to not change, got a diff for %s", k)
+		}
+		if strings.HasPrefix(k, "tags_all.") {
+			changed = true
+		}
+	}
+
+	if !changed {
+		t.Error("expected changing the default tags to update tags_all")
+	}
+}
@@ -0,0 +1,269 @@
+package statuscake
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// throttleTransport limits the rate and number of concurrent requests, and is
+// shared by every resource & data source so that they are throttled together
+type throttleTransport struct {
+	next      http.RoundTripper
+	limiter   *rate.Limiter
+	semaphore chan struct{}
+}
+
+// newThrottleTransport returns a transport allowing the given number of requests
+// per second and concurrent requests, where 0 means there is no limit
+func newThrottleTransport(next http.RoundTripper, requestsPerSecond float64, maxConcurrentRequests int) *throttleTransport {
+	t := &throttleTransport{next: next}
+
+	if requestsPerSecond > 0 {
+		burst := int(requestsPerSecond)
+
+		if burst < 1 {
+			burst = 1
+		}
+
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+
+	if maxConcurrentRequests > 0 {
+		t.semaphore = make(chan struct{}, maxConcurrentRequests)
+	}
+
+	return t
+}
+
+func (t *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.semaphore == nil {
+		if err := t.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		return t.next.RoundTrip(req)
+	}
+
+	select {
+	case t.semaphore <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	release := func() { <-t.semaphore }
+
+	if err := t.wait(ctx); err != nil {
+		release()
+
+		return nil, err
+	}
+
+	res, err := t.next.RoundTrip(req)
+
+	if err != nil {
+		release()
+
+		return nil, err
+	}
+
+	// the request isn't finished until the body has been read, so hold onto the
+	// slot until then
+	res.Body = &releasingReadCloser{ReadCloser: res.Body, release: release}
+
+	return res, nil
+}
+
+func (t *throttleTransport) wait(ctx context.Context) error {
+	if t.limiter == nil {
+		return nil
+	}
+
+	return t.limiter.Wait(ctx)
+}
+
+// releasingReadCloser calls release the first time it is closed
+type releasingReadCloser struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (r *releasingReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+
+	r.once.Do(r.release)
+
+	return err
+}
+
+// waitForContext blocks until either the duration has passed or the context is done
+func waitForContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryTransport retries requests that fail because of rate limiting, server
+// errors or connection problems, waiting with an exponential backoff between
+// each attempt
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, maxWait time.Duration) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		minWait:    time.Second,
+		maxWait:    maxWait,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attemptReq := req
+
+	for attempt := 0; ; attempt++ {
+		res, err := t.next.RoundTrip(attemptReq)
+
+		if attempt >= t.maxRetries || !shouldRetryRequest(req, res, err) {
+			return res, err
+		}
+
+		// we can only retry if we're able to send the same body again
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return res, err
+		}
+
+		wait := t.backoff(attempt, res)
+
+		if res != nil {
+			tflog.SubsystemDebug(req.Context(), logSubsystemAPI, "Retrying StatusCake API request", map[string]interface{}{
+				"http_method": req.Method,
+				"http_path":   req.URL.Path,
+				"http_status": res.StatusCode,
+				"attempt":     attempt + 1,
+				"wait_ms":     wait.Milliseconds(),
+			})
+
+			// drain the body so that the connection can be reused
+			_, _ = io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		} else {
+			tflog.SubsystemDebug(req.Context(), logSubsystemAPI, "Retrying failed StatusCake API request", map[string]interface{}{
+				"http_method": req.Method,
+				"http_path":   req.URL.Path,
+				"error":       err.Error(),
+				"attempt":     attempt + 1,
+				"wait_ms":     wait.Milliseconds(),
+			})
+		}
+
+		if err := waitForContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+
+		attemptReq = req.Clone(req.Context())
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+
+			if err != nil {
+				return nil, err
+			}
+
+			attemptReq.Body = body
+		}
+	}
+}
+
+// backoff returns how long to wait before the given (zero based) retry attempt,
+// preferring the duration given by a Retry-After header if there is one
+func (t *retryTransport) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			if wait > t.maxWait {
+				return t.maxWait
+			}
+
+			return wait
+		}
+	}
+
+	wait := t.minWait << uint(attempt)
+
+	if wait > t.maxWait || wait <= 0 {
+		wait = t.maxWait
+	}
+
+	// add jitter so that parallel requests don't all retry at the same time
+	half := wait / 2
+
+	return half + time.Duration(rand.Int63n(int64(half)+1)) //nolint:gosec
+}
+
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(header); err == nil {
+		wait := time.Until(at)
+
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// shouldRetryRequest determines if a request can be safely retried based on its
+// response or error.
+//
+// requests that are rate limited are always retried, but as creating things is
+// not idempotent a POST is otherwise never retried as the original request could
+// have been processed and retrying would create duplicates
+func shouldRetryRequest(req *http.Request, res *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err == nil {
+		if res.StatusCode == http.StatusTooManyRequests {
+			return true
+		}
+
+		return res.StatusCode >= 500 && req.Method != http.MethodPost
+	}
+
+	if req.Method == http.MethodPost {
+		return false
+	}
+
+	return classifyError(err).transient()
+}
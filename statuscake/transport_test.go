@@ -0,0 +1,376 @@
+package statuscake
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestRetryClient(maxRetries int) *http.Client {
+	transport := newRetryTransport(http.DefaultTransport, maxRetries, 50*time.Millisecond)
+	transport.minWait = time.Millisecond
+
+	return &http.Client{Transport: transport}
+}
+
+func TestRetryTransport_retriesTransientErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable} {
+		status := status
+
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+
+				if string(body) != "name=My+Site" {
+					t.Errorf("unexpected body on attempt %d: %q", atomic.LoadInt32(&calls), body)
+				}
+
+				if atomic.AddInt32(&calls, 1) < 3 {
+					w.WriteHeader(status)
+
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			req, _ := http.NewRequest(http.MethodPut, server.URL, strings.NewReader("name=My+Site"))
+			res, err := newTestRetryClient(4).Do(req)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			defer res.Body.Close()
+
+			if res.StatusCode != http.StatusOK {
+				t.Errorf("expected the request to eventually succeed, got %d", res.StatusCode)
+			}
+			if got := atomic.LoadInt32(&calls); got != 3 {
+				t.Errorf("expected 3 attempts, got %d", got)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_givesUpAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	res, err := newTestRetryClient(2).Get(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected the last response to be returned, got %d", res.StatusCode)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestRetryTransport_doesNotRetryClientErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	res, err := newTestRetryClient(4).Get(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	defer res.Body.Close()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestRetryTransport_onlyRetriesPostWhenRateLimited(t *testing.T) {
+	t.Parallel()
+
+	tests := map[int]int32{
+		http.StatusTooManyRequests:     2,
+		http.StatusInternalServerError: 1,
+	}
+
+	for status, want := range tests {
+		status, want := status, want
+
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					w.WriteHeader(status)
+
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			res, err := newTestRetryClient(4).Post(server.URL, "application/x-www-form-urlencoded", strings.NewReader("name=My+Site"))
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			defer res.Body.Close()
+
+			if got := atomic.LoadInt32(&calls); got != want {
+				t.Errorf("expected %d attempts, got %d", want, got)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_retriesConnectionErrors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	var attempts int32
+
+	transport := newRetryTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&attempts, 1)
+
+		return http.DefaultTransport.RoundTrip(req)
+	}), 2, 10*time.Millisecond)
+	transport.minWait = time.Millisecond
+
+	res, err := (&http.Client{Transport: transport}).Get(url)
+
+	if err == nil {
+		res.Body.Close()
+		t.Fatal("expected an error")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestRetryTransport_respectsRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := newRetryTransport(http.DefaultTransport, 1, 2*time.Second)
+	transport.minWait = time.Millisecond
+
+	start := time.Now()
+	res, err := (&http.Client{Transport: transport}).Get(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	defer res.Body.Close()
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected to wait at least a second, waited %s", elapsed)
+	}
+}
+
+func TestRetryTransport_stopsWhenContextIsCancelled(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	transport := newRetryTransport(http.DefaultTransport, 4, time.Minute)
+
+	start := time.Now()
+	res, err := (&http.Client{Transport: transport}).Do(req)
+
+	if err == nil {
+		res.Body.Close()
+		t.Fatal("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected to stop waiting once the context was cancelled, waited %s", elapsed)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	if wait, ok := parseRetryAfter("120"); !ok || wait != 2*time.Minute {
+		t.Errorf("expected 2m, got %s (%t)", wait, ok)
+	}
+
+	if wait, ok := parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)); !ok || wait < 59*time.Minute {
+		t.Errorf("expected about 1h, got %s (%t)", wait, ok)
+	}
+
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("expected an invalid header to be ignored")
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestThrottleTransport_limitsConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, maxInFlight int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			previous := atomic.LoadInt32(&maxInFlight)
+
+			if current <= previous || atomic.CompareAndSwapInt32(&maxInFlight, previous, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newThrottleTransport(http.DefaultTransport, 0, 2)}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			res, err := client.Get(server.URL)
+
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+
+				return
+			}
+
+			_, _ = io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}()
+	}
+
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&maxInFlight); got > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", got)
+	}
+}
+
+func TestThrottleTransport_limitsRequestRate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newThrottleTransport(http.DefaultTransport, 20, 0)}
+
+	start := time.Now()
+
+	// the first 20 requests are allowed immediately, and then the next 10 should
+	// take about half a second
+	for i := 0; i < 30; i++ {
+		res, err := client.Get(server.URL)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		res.Body.Close()
+	}
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("expected requests to be rate limited, took %s", elapsed)
+	}
+}
+
+func TestThrottleTransport_stopsWaitingWhenContextIsCancelled(t *testing.T) {
+	t.Parallel()
+
+	transport := newThrottleTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Error("expected the request to not be sent")
+
+		return nil, context.Canceled
+	}), 0, 1)
+
+	// take the only slot
+	transport.semaphore <- struct{}{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+
+	if _, err := transport.RoundTrip(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to be exceeded, got %v", err)
+	}
+}
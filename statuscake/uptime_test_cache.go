@@ -0,0 +1,114 @@
+package statuscake
+
+import (
+	"context"
+	"github.com/StatusCakeDev/statuscake-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"sync"
+)
+
+// uptimeTestCache holds an overview of every uptime test in the account, listed
+// once the first time an uptime test is refreshed, so that tests deleted outside
+// of terraform are found without a request for each of them
+type uptimeTestCache struct {
+	mu     sync.Mutex
+	tests  map[string]statuscake.UptimeTestOverview
+	failed bool
+}
+
+// lookup returns the overview of the uptime test from the list of all uptime
+// tests, listing them if they haven't been already. ok is false whenever the
+// list can't be used, such as when it failed or the test was just written, in
+// which case whether the test exists is only known once it's got
+func (c *uptimeTestCache) lookup(ctx context.Context, client *statuscake.APIClient, id string) (test statuscake.UptimeTestOverview, found, ok bool) {
+	if c == nil || isReadAfterWrite(ctx) {
+		return test, false, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tests == nil && !c.failed {
+		tests, err := listUptimeTests(ctx, client)
+
+		if err != nil {
+			logStatusCakeAPIError(ctx, err)
+			tflog.SubsystemWarn(ctx, logSubsystemResource, "Could not list uptime tests, each test will be read individually")
+
+			// only give up on the list when the api failed, rather than when this read
+			// was cancelled
+			c.failed = ctx.Err() == nil
+
+			return test, false, false
+		}
+
+		c.tests = tests
+	}
+
+	if c.failed {
+		return test, false, false
+	}
+
+	test, found = c.tests[id]
+
+	return test, found, true
+}
+
+// invalidate forgets the uptime tests, so that they are listed again by the next
+// refresh
+func (c *uptimeTestCache) invalidate() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.tests = nil
+	c.failed = false
+}
+
+func listUptimeTests(ctx context.Context, client *statuscake.APIClient) (map[string]statuscake.UptimeTestOverview, error) {
+	tests := make(map[string]statuscake.UptimeTestOverview)
+
+	err := listUptimeTestPages(ctx, client, defaultPageSize, func(page []statuscake.UptimeTestOverview) bool {
+		for _, test := range page {
+			tests[test.ID] = test
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tests, nil
+}
+
+// invalidateCacheOnWrite wraps the create, update and delete functions of a
+// resource so that the cached uptime tests are forgotten after anything is
+// changed, as e.g. deleting a contact group also changes the uptime tests
+func invalidateCacheOnWrite(r *schema.Resource) *schema.Resource {
+	type operationFunc = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+	wrap := func(fn operationFunc) operationFunc {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			diags := fn(ctx, d, meta)
+
+			if m, ok := meta.(*providerMeta); ok {
+				m.uptimeTests.invalidate()
+			}
+
+			return diags
+		}
+	}
+
+	r.CreateContext = wrap(r.CreateContext)
+	r.UpdateContext = wrap(r.UpdateContext)
+	r.DeleteContext = wrap(r.DeleteContext)
+
+	return r
+}
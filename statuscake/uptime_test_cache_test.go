@@ -0,0 +1,224 @@
+package statuscake
+
+import (
+	"context"
+	"fmt"
+	"github.com/StatusCakeDev/statuscake-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// uptimeTestListHandler lists uptime tests 1 & 2 on the first page and test 3 on
+// the second, counting the requests to list and get the tests. Only the tests
+// that are listed can be got
+func uptimeTestListHandler(lists, gets *int32, listStatus int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path != "/v1/uptime" {
+			atomic.AddInt32(gets, 1)
+
+			switch id := strings.TrimPrefix(r.URL.Path, "/v1/uptime/"); id {
+			case "1", "2", "3":
+				fmt.Fprintf(w, `{"data":{"id":"%[1]s","name":"test %[1]s","website_url":"https://www.example.com/%[1]s","test_type":"HTTP","check_rate":300,"contact_groups":["10"],"paused":true,"tags":["listed"],"timeout":30,"confirmation":2}}`, id)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"No results found","errors":{}}`))
+
+			return
+		}
+
+		atomic.AddInt32(lists, 1)
+
+		if listStatus != 0 {
+			w.WriteHeader(listStatus)
+			_, _ = w.Write([]byte(`{"message":"Something went wrong","errors":{}}`))
+
+			return
+		}
+
+		tests := uptimeTestOverviewJSON("1") + "," + uptimeTestOverviewJSON("2")
+
+		if r.URL.Query().Get("page") == "2" {
+			tests = uptimeTestOverviewJSON("3")
+		}
+
+		fmt.Fprintf(w, `{"data":[%s],"metadata":{"page":%s,"per_page":2,"page_count":2,"total_count":3}}`, tests, r.URL.Query().Get("page"))
+	}
+}
+
+func uptimeTestOverviewJSON(id string) string {
+	return fmt.Sprintf(`{"id":"%[1]s","name":"test %[1]s","website_url":"https://www.example.com/%[1]s","test_type":"HTTP","check_rate":300,"contact_groups":["10"],"paused":true,"status":"up","tags":["listed"],"uptime":100}`, id)
+}
+
+func TestUptimeTestCache(t *testing.T) {
+	t.Parallel()
+
+	var lists, gets int32
+
+	client := newTestAPIClient(t, uptimeTestListHandler(&lists, &gets, 0))
+	cache := &uptimeTestCache{}
+	ctx := context.Background()
+
+	for id, want := range map[string]bool{"1": true, "2": true, "3": true, "4": false} {
+		test, found, ok := cache.lookup(ctx, client, id)
+
+		if !ok || found != want {
+			t.Errorf("expected uptime test %s to be found to be %t, got %t", id, want, found)
+		}
+		if found && test.Name != "test "+id {
+			t.Errorf("expected the overview of uptime test %s, got %+v", id, test)
+		}
+	}
+
+	if lists != 2 {
+		t.Errorf("expected both pages to be listed once, got %d requests", lists)
+	}
+
+	cache.invalidate()
+	cache.lookup(ctx, client, "1")
+
+	if lists != 4 {
+		t.Errorf("expected the uptime tests to be listed again after being invalidated, got %d requests", lists)
+	}
+}
+
+func TestUptimeTestCache_listFails(t *testing.T) {
+	t.Parallel()
+
+	var lists, gets int32
+
+	client := newTestAPIClient(t, uptimeTestListHandler(&lists, &gets, http.StatusBadRequest))
+	cache := &uptimeTestCache{}
+
+	for _, id := range []string{"1", "4"} {
+		if _, _, ok := cache.lookup(context.Background(), client, id); ok {
+			t.Errorf("expected uptime test %s to be read individually", id)
+		}
+	}
+
+	if lists != 1 {
+		t.Errorf("expected the failed list not to be retried, got %d requests", lists)
+	}
+}
+
+func TestUptimeTestCache_readAfterWrite(t *testing.T) {
+	t.Parallel()
+
+	var lists, gets int32
+
+	client := newTestAPIClient(t, uptimeTestListHandler(&lists, &gets, 0))
+	ctx := context.WithValue(context.Background(), readAfterWriteKey{}, true)
+
+	if _, _, ok := (&uptimeTestCache{}).lookup(ctx, client, "4"); ok {
+		t.Error("expected a test that was just written to be read individually")
+	}
+	if lists != 0 {
+		t.Errorf("expected the uptime tests not to be listed, got %d requests", lists)
+	}
+}
+
+func TestUptimeTestRead_usesCache(t *testing.T) {
+	t.Parallel()
+
+	var lists, gets int32
+
+	client := newTestAPIClient(t, uptimeTestListHandler(&lists, &gets, 0))
+	meta := &providerMeta{client: client, uptimeTests: &uptimeTestCache{}}
+
+	for _, id := range []string{"4", "5"} {
+		d := ResourceStatusCakeUptimeTest().TestResourceData()
+		d.SetId(id)
+
+		diags := resourceStatusCakeUptimeTestRead(context.Background(), d, meta)
+
+		if d.Id() != "" || len(diags) != 1 || diags[0].Severity != diag.Warning {
+			t.Errorf("expected uptime test %s to be removed with a warning, got %v", id, diags)
+		}
+	}
+
+	if gets != 0 {
+		t.Errorf("expected no uptime tests to be got, got %d requests", gets)
+	}
+	if lists != 2 {
+		t.Errorf("expected the uptime tests to be listed once, got %d requests", lists)
+	}
+}
+
+func TestUptimeTestRead_refreshesFromCache(t *testing.T) {
+	t.Parallel()
+
+	var lists, gets int32
+
+	client := newTestAPIClient(t, uptimeTestListHandler(&lists, &gets, 0))
+	meta := &providerMeta{client: client, uptimeTests: &uptimeTestCache{}}
+
+	for _, id := range []string{"1", "2"} {
+		d := ResourceStatusCakeUptimeTest().TestResourceData()
+		d.SetId(id)
+		for k, v := range map[string]interface{}{"name": "old", "test_type": "HTTP", "check_rate": 60, "timeout": 15} {
+			if err := d.Set(k, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if diags := resourceStatusCakeUptimeTestRead(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("expected uptime test %s to be refreshed, got %v", id, diags)
+		}
+
+		if d.Get("name") != "test "+id || d.Get("check_rate") != 300 || d.Get("paused") != true {
+			t.Errorf("expected uptime test %s to be refreshed from the list, got %v", id, d.State().Attributes)
+		}
+		if d.Get("timeout") != 30 || d.Get("confirmation") != 2 {
+			t.Errorf("expected the attributes of uptime test %s that aren't listed to be refreshed, got %v", id, d.State().Attributes)
+		}
+	}
+
+	if gets != 2 {
+		t.Errorf("expected each uptime test to be got, got %d requests", gets)
+	}
+	if lists != 2 {
+		t.Errorf("expected the uptime tests to be listed once, got %d requests", lists)
+	}
+}
+
+func TestInvalidateCacheOnWrite(t *testing.T) {
+	t.Parallel()
+
+	op := func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return nil
+	}
+
+	r := invalidateCacheOnWrite(&schema.Resource{CreateContext: op, ReadContext: op, UpdateContext: op, DeleteContext: op})
+
+	writes := map[string]func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics{
+		"create": r.CreateContext,
+		"update": r.UpdateContext,
+		"delete": r.DeleteContext,
+	}
+
+	for name, fn := range writes {
+		cache := &uptimeTestCache{tests: map[string]statuscake.UptimeTestOverview{}}
+
+		fn(context.Background(), r.TestResourceData(), &providerMeta{uptimeTests: cache})
+
+		if cache.tests != nil {
+			t.Errorf("expected %s to invalidate the cache", name)
+		}
+	}
+
+	cache := &uptimeTestCache{tests: map[string]statuscake.UptimeTestOverview{}}
+
+	r.ReadContext(context.Background(), r.TestResourceData(), &providerMeta{uptimeTests: cache})
+
+	if cache.tests == nil {
+		t.Error("expected read not to invalidate the cache")
+	}
+}
@@ -0,0 +1,148 @@
+package statuscake
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"reflect"
+)
+
+// uptimeTestDefaultAttributes are the attributes of an uptime test that can be
+// given a default value in the provider uptime_test_defaults block
+var uptimeTestDefaultAttributes = []string{ //nolint:gochecknoglobals
+	"check_rate",
+	"confirmation",
+	"contact_groups",
+	"regions",
+	"timeout",
+	"trigger_rate",
+	"user_agent",
+}
+
+// uptimeTestBuiltinDefaults are used when an attribute isn't set on either the
+// uptime test or in the provider uptime_test_defaults block
+var uptimeTestBuiltinDefaults = map[string]interface{}{ //nolint:gochecknoglobals
+	"confirmation":   2,
+	"contact_groups": []string{},
+	"timeout":        40,
+	"trigger_rate":   4,
+	"user_agent":     "",
+}
+
+// uptimeTestDefaultsSchema returns the schema of the provider uptime_test_defaults
+// block, which mirrors the uptime test attributes so that they're validated the
+// same way
+func uptimeTestDefaultsSchema() map[string]*schema.Schema {
+	resource := ResourceStatusCakeUptimeTest().Schema
+	s := make(map[string]*schema.Schema, len(uptimeTestDefaultAttributes))
+
+	for _, key := range uptimeTestDefaultAttributes {
+		attr := resource[key]
+
+		s[key] = &schema.Schema{
+			Type:         attr.Type,
+			Elem:         attr.Elem,
+			Optional:     true,
+			ValidateFunc: attr.ValidateFunc,
+			Description:  fmt.Sprintf("Default `%s` of uptime tests that don't set it", key),
+		}
+	}
+
+	return s
+}
+
+// expandUptimeTestDefaults returns the values set in the provider
+// uptime_test_defaults block, keyed by the uptime test attribute
+func expandUptimeTestDefaults(d *schema.ResourceData) map[string]interface{} {
+	defaults := make(map[string]interface{})
+
+	for _, key := range uptimeTestDefaultAttributes {
+		path := "uptime_test_defaults.0." + key
+
+		switch v := d.Get(path).(type) {
+		case []interface{}:
+			if len(v) > 0 {
+				defaults[key] = asListOfStrings(v)
+			}
+		default:
+			// zero is a meaningful value for some of the numbers, so we need to
+			// check whether it was actually set
+			//nolint:staticcheck
+			if v, ok := d.GetOkExists(path); ok {
+				defaults[key] = v
+			}
+		}
+	}
+
+	return defaults
+}
+
+// uptimeTestDiff is the part of *schema.ResourceDiff needed to apply the defaults
+type uptimeTestDiff interface {
+	Id() string
+	Get(key string) interface{}
+	GetRawConfig() cty.Value
+	SetNew(key string, value interface{}) error
+}
+
+func customizeDiffUptimeTestDefaults(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	var defaults map[string]interface{}
+
+	if m, ok := meta.(*providerMeta); ok {
+		defaults = m.uptimeTestDefaults
+	}
+
+	return applyUptimeTestDefaults(d, defaults)
+}
+
+// applyUptimeTestDefaults plans the effective value of every attribute that isn't
+// set in the configuration, taken from the provider defaults or otherwise the
+// builtin defaults, so that the plan shows what will actually be used
+func applyUptimeTestDefaults(d uptimeTestDiff, defaults map[string]interface{}) error {
+	config := d.GetRawConfig()
+
+	// without the configuration we can't tell what has been set
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	for _, key := range uptimeTestDefaultAttributes {
+		if !config.GetAttr(key).IsNull() {
+			continue
+		}
+
+		value, ok := defaults[key]
+
+		if !ok {
+			value, ok = uptimeTestBuiltinDefaults[key]
+		}
+
+		if !ok {
+			if key == "check_rate" {
+				return fmt.Errorf("%q must be set, either on the uptime test or in the provider uptime_test_defaults block", key)
+			}
+
+			// there's no default, so let the api decide
+			continue
+		}
+
+		if d.Id() != "" && sameDefaultValue(d.Get(key), value) {
+			continue
+		}
+
+		if err := d.SetNew(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sameDefaultValue(current, value interface{}) bool {
+	if list, ok := current.([]interface{}); ok {
+		return reflect.DeepEqual(asListOfStrings(list), value)
+	}
+
+	return current == value
+}
@@ -0,0 +1,217 @@
+package statuscake
+
+import (
+	"context"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"reflect"
+	"testing"
+)
+
+// fakeUptimeTestDiff records the values planned by applyUptimeTestDefaults
+type fakeUptimeTestDiff struct {
+	id      string
+	state   map[string]interface{}
+	config  cty.Value
+	planned map[string]interface{}
+}
+
+func (d *fakeUptimeTestDiff) Id() string {
+	return d.id
+}
+
+func (d *fakeUptimeTestDiff) Get(key string) interface{} {
+	return d.state[key]
+}
+
+func (d *fakeUptimeTestDiff) GetRawConfig() cty.Value {
+	return d.config
+}
+
+func (d *fakeUptimeTestDiff) SetNew(key string, value interface{}) error {
+	d.planned[key] = value
+
+	return nil
+}
+
+// uptimeTestConfig returns the raw configuration of an uptime test with only the
+// given attributes set
+func uptimeTestConfig(attrs map[string]cty.Value) cty.Value {
+	ty := ResourceStatusCakeUptimeTest().CoreConfigSchema().ImpliedType()
+	values := make(map[string]cty.Value)
+
+	for name, attrType := range ty.AttributeTypes() {
+		if v, ok := attrs[name]; ok {
+			values[name] = v
+		} else {
+			values[name] = cty.NullVal(attrType)
+		}
+	}
+
+	return cty.ObjectVal(values)
+}
+
+func TestApplyUptimeTestDefaults(t *testing.T) {
+	t.Parallel()
+
+	providerDefaults := map[string]interface{}{
+		"check_rate":     60,
+		"confirmation":   3,
+		"contact_groups": []string{"123"},
+		"regions":        []string{"london", "sydney"},
+		"trigger_rate":   0,
+	}
+
+	tests := []struct {
+		name     string
+		id       string
+		state    map[string]interface{}
+		attrs    map[string]cty.Value
+		defaults map[string]interface{}
+		want     map[string]interface{}
+	}{
+		{
+			name:     "builtin defaults",
+			attrs:    map[string]cty.Value{"check_rate": cty.NumberIntVal(300)},
+			defaults: nil,
+			want: map[string]interface{}{
+				"confirmation":   2,
+				"contact_groups": []string{},
+				"timeout":        40,
+				"trigger_rate":   4,
+				"user_agent":     "",
+			},
+		},
+		{
+			name:     "provider defaults",
+			attrs:    map[string]cty.Value{},
+			defaults: providerDefaults,
+			want: map[string]interface{}{
+				"check_rate":     60,
+				"confirmation":   3,
+				"contact_groups": []string{"123"},
+				"regions":        []string{"london", "sydney"},
+				"timeout":        40,
+				"trigger_rate":   0,
+				"user_agent":     "",
+			},
+		},
+		{
+			name: "configured values win",
+			attrs: map[string]cty.Value{
+				"check_rate":     cty.NumberIntVal(300),
+				"confirmation":   cty.NumberIntVal(1),
+				"contact_groups": cty.ListValEmpty(cty.String),
+				"regions":        cty.ListVal([]cty.Value{cty.StringVal("tokyo")}),
+				"timeout":        cty.NumberIntVal(20),
+				"trigger_rate":   cty.NumberIntVal(5),
+				"user_agent":     cty.StringVal("my-agent"),
+			},
+			defaults: providerDefaults,
+			want:     map[string]interface{}{},
+		},
+		{
+			name: "unchanged existing test",
+			id:   "123",
+			state: map[string]interface{}{
+				"check_rate":     60,
+				"confirmation":   3,
+				"contact_groups": []interface{}{"123"},
+				"regions":        []interface{}{"london", "sydney"},
+				"timeout":        40,
+				"trigger_rate":   0,
+				"user_agent":     "",
+			},
+			attrs:    map[string]cty.Value{},
+			defaults: providerDefaults,
+			want:     map[string]interface{}{},
+		},
+		{
+			name: "changed defaults",
+			id:   "123",
+			state: map[string]interface{}{
+				"check_rate":     300,
+				"confirmation":   3,
+				"contact_groups": []interface{}{"456"},
+				"regions":        []interface{}{"london", "sydney"},
+				"timeout":        40,
+				"trigger_rate":   0,
+				"user_agent":     "",
+			},
+			attrs:    map[string]cty.Value{},
+			defaults: providerDefaults,
+			want: map[string]interface{}{
+				"check_rate":     60,
+				"contact_groups": []string{"123"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &fakeUptimeTestDiff{
+				id:      tt.id,
+				state:   tt.state,
+				config:  uptimeTestConfig(tt.attrs),
+				planned: map[string]interface{}{},
+			}
+
+			if err := applyUptimeTestDefaults(d, tt.defaults); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(d.planned, tt.want) {
+				t.Errorf("expected %v to be planned, got %v", tt.want, d.planned)
+			}
+		})
+	}
+}
+
+func TestApplyUptimeTestDefaults_missingCheckRate(t *testing.T) {
+	t.Parallel()
+
+	d := &fakeUptimeTestDiff{
+		config:  uptimeTestConfig(map[string]cty.Value{}),
+		planned: map[string]interface{}{},
+	}
+
+	if err := applyUptimeTestDefaults(d, nil); err == nil {
+		t.Error("expected an error when check_rate isn't set anywhere")
+	}
+}
+
+func TestExpandUptimeTestDefaults(t *testing.T) {
+	t.Parallel()
+
+	p := New("dev")()
+
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"skip_credentials_validation": true,
+		"api_key":                     "my-api-key",
+		"uptime_test_defaults": []interface{}{
+			map[string]interface{}{
+				"check_rate":   60,
+				"trigger_rate": 0,
+				"regions":      []interface{}{"london"},
+			},
+		},
+	}))
+
+	if diags.HasError() {
+		t.Fatalf("unexpected error configuring provider: %v", diags)
+	}
+
+	want := map[string]interface{}{
+		"check_rate":   60,
+		"trigger_rate": 0,
+		"regions":      []string{"london"},
+	}
+
+	if got := p.Meta().(*providerMeta).uptimeTestDefaults; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -1,13 +1,41 @@
 package statuscake
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
+	"fmt"
 	"github.com/StatusCakeDev/statuscake-go"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// defaultResourceTimeouts returns how long each operation of a resource can take,
+// including any retries, before the in-flight request is cancelled. These can be
+// overridden with a timeouts block
+func defaultResourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(5 * time.Minute),
+		Read:   schema.DefaultTimeout(5 * time.Minute),
+		Update: schema.DefaultTimeout(5 * time.Minute),
+		Delete: schema.DefaultTimeout(5 * time.Minute),
+	}
+}
+
+// detachedContext keeps the values of a context, such as its loggers, without its
+// deadline or cancellation
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
 func asListOfStrings(list interface{}) []string {
 	strings := make([]string, 0, len(list.([]interface{})))
 
@@ -18,63 +46,101 @@ func asListOfStrings(list interface{}) []string {
 	return strings
 }
 
-func apiErrorDiag(err error) diag.Diagnostics {
-	var apiError statuscake.APIError
-	var diags diag.Diagnostics
-
-	if !errors.As(err, &apiError) {
-		return diag.Errorf("Unknown error (received error that unexpectedly not an api error)")
-	}
+func asListOfIntStrings(list interface{}) []string {
+	strings := make([]string, 0, len(list.([]interface{})))
 
-	for _, errs := range apiError.Errors {
-		for _, message := range errs {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  message,
-			})
-		}
+	for _, item := range list.([]interface{}) {
+		strings = append(strings, strconv.Itoa(item.(int)))
 	}
 
-	return diags
+	return strings
 }
 
-func isNotFoundAPIError(err error) bool {
-	var apiError statuscake.APIError
+func asListOfInts(list []int32) []int {
+	ints := make([]int, 0, len(list))
 
-	return errors.As(err, &apiError) && apiError.Status == 404
-}
+	for _, item := range list {
+		ints = append(ints, int(item))
+	}
 
-func logResponse(res interface{}) {
-	log.Printf("[DEBUG] StatusCake API Response: %s", prettifyObject(res))
+	return ints
 }
 
-func prettifyObject(obj interface{}) string {
-	pretty, err := json.MarshalIndent(obj, "", "  ")
+// apiFieldAttributes maps the names of the fields used by the api to the
+// attributes of the resource, which have the same name unless they're renamed
+func apiFieldAttributes(s map[string]*schema.Schema, renamed map[string]string) map[string]string {
+	fields := make(map[string]string, len(s)+len(renamed))
 
-	if err != nil {
-		log.Printf("[WARN] Failed to make object pretty: %s", err)
+	for name := range s {
+		fields[name] = name
+	}
 
-		return "<ERROR>"
+	for field, name := range renamed {
+		fields[field] = name
 	}
 
-	return string(pretty)
+	return fields
 }
 
-func logStatusCakeAPIError(err error) {
-	var statuscakeError statuscake.APIError
+// apiErrorDiag returns a diagnostic for each field error returned by the api,
+// pointing at the attribute the field is set from where there is one
+func apiErrorDiag(err error, fields map[string]string) diag.Diagnostics {
+	var apiError statuscake.APIError
+	var diags diag.Diagnostics
 
-	if !errors.As(err, &statuscakeError) {
-		log.Printf("[WARN] Was provided an error that was not from StatusCake API")
-		log.Printf("[DEBUG] Error: %s", err)
+	if errors.Is(err, errDryRun) {
+		return diag.Errorf("%s", errDryRun)
+	}
 
-		return
+	if classifyError(err) != errorClassAPI || !errors.As(err, &apiError) {
+		return errorDiag(err)
 	}
 
-	pretty, err := json.MarshalIndent(statuscakeError, "", "  ")
+	detail := fmt.Sprintf("The StatusCake API returned HTTP %d: %s", apiError.Status, apiError.Message)
 
-	if err != nil {
-		log.Printf("[WARN] Failed to make StatusCake API error pretty: %s", err)
-	} else {
-		log.Printf("[DEBUG] StatusCake API returned an error: %s", pretty)
+	// sort the fields so that the errors are always in the same order
+	names := make([]string, 0, len(apiError.Errors))
+
+	for field := range apiError.Errors {
+		names = append(names, field)
 	}
+
+	sort.Strings(names)
+
+	for _, field := range names {
+		var path cty.Path
+
+		// lists are sent as comma separated values, so e.g. the tags attribute is
+		// sent as the tags_csv field
+		if attribute, ok := fields[field]; ok {
+			path = cty.GetAttrPath(attribute)
+		} else if attribute, ok := fields[strings.TrimSuffix(field, "_csv")]; ok {
+			path = cty.GetAttrPath(attribute)
+		}
+
+		for _, message := range apiError.Errors[field] {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       message,
+				Detail:        detail,
+				AttributePath: path,
+			})
+		}
+	}
+
+	if len(diags) == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  apiError.Message,
+			Detail:   detail,
+		})
+	}
+
+	return diags
+}
+
+func isNotFoundAPIError(err error) bool {
+	var apiError statuscake.APIError
+
+	return errors.As(err, &apiError) && apiError.Status == 404
 }
@@ -0,0 +1,65 @@
+package statuscake
+
+import (
+	"github.com/StatusCakeDev/statuscake-go"
+	"github.com/hashicorp/go-cty/cty"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorDiag(t *testing.T) {
+	t.Parallel()
+
+	err := statuscake.APIError{
+		Status:  400,
+		Message: "The provided parameters are invalid",
+		Errors: map[string][]string{
+			"website_url": {"Website URL must be a valid URL"},
+			"check_rate":  {"Check rate must be one of the allowed values"},
+			"use_jar":     {"Use jar must be a boolean"},
+			"tags_csv":    {"Tags must not be longer than 255 characters"},
+			"unknown":     {"Something else is wrong"},
+		},
+	}
+
+	diags := apiErrorDiag(err, uptimeTestAPIFields())
+
+	want := map[string]cty.Path{
+		"Website URL must be a valid URL":              cty.GetAttrPath("website_url"),
+		"Check rate must be one of the allowed values": cty.GetAttrPath("check_rate"),
+		"Use jar must be a boolean":                    cty.GetAttrPath("cookie_storage"),
+		"Tags must not be longer than 255 characters":  cty.GetAttrPath("tags"),
+		"Something else is wrong":                      nil,
+	}
+
+	if len(diags) != len(want) {
+		t.Fatalf("expected %d diagnostics, got %v", len(want), diags)
+	}
+
+	for _, d := range diags {
+		path, ok := want[d.Summary]
+
+		if !ok {
+			t.Errorf("unexpected diagnostic: %s", d.Summary)
+
+			continue
+		}
+
+		if !d.AttributePath.Equals(path) {
+			t.Errorf("expected %q to point at %#v, got %#v", d.Summary, path, d.AttributePath)
+		}
+		if !strings.Contains(d.Detail, "HTTP 400") || !strings.Contains(d.Detail, err.Message) {
+			t.Errorf("expected the status and message in the detail, got %q", d.Detail)
+		}
+	}
+}
+
+func TestAPIErrorDiag_withoutFieldErrors(t *testing.T) {
+	t.Parallel()
+
+	diags := apiErrorDiag(statuscake.APIError{Status: 500, Message: "Internal server error"}, uptimeTestAPIFields())
+
+	if len(diags) != 1 || diags[0].Summary != "Internal server error" || !strings.Contains(diags[0].Detail, "HTTP 500") {
+		t.Errorf("expected a single diagnostic with the message, got %v", diags)
+	}
+}
@@ -0,0 +1,97 @@
+package statuscake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"io/ioutil"
+	"net/http"
+)
+
+// guardWrites wraps the create, update and delete functions of a resource so that
+// they fail before reaching the api when the provider is read only
+func guardWrites(name string, r *schema.Resource) *schema.Resource {
+	create, update, del := r.CreateContext, r.UpdateContext, r.DeleteContext
+
+	r.CreateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if diags := checkWritable(meta, "create", name, d.Id()); diags != nil {
+			return diags
+		}
+
+		return create(ctx, d, meta)
+	}
+	r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if diags := checkWritable(meta, "update", name, d.Id()); diags != nil {
+			return diags
+		}
+
+		return update(ctx, d, meta)
+	}
+	r.DeleteContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if diags := checkWritable(meta, "delete", name, d.Id()); diags != nil {
+			return diags
+		}
+
+		return del(ctx, d, meta)
+	}
+
+	return r
+}
+
+func checkWritable(meta interface{}, operation, name, id string) diag.Diagnostics {
+	if m, ok := meta.(*providerMeta); !ok || !m.readOnly {
+		return nil
+	}
+
+	target := name
+
+	if id != "" {
+		target = fmt.Sprintf("%s %s", name, id)
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  "The StatusCake provider is read only",
+			Detail:   fmt.Sprintf("Refusing to %s %s because the provider is configured with read_only = true. Reads and data sources still work.", operation, target),
+		},
+	}
+}
+
+var errDryRun = errors.New("dry run, the request was not sent to StatusCake")
+
+// dryRunTransport logs and then drops every request that would change something,
+// while still sending requests that only read
+type dryRunTransport struct {
+	next http.RoundTripper
+}
+
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return t.next.RoundTrip(req)
+	}
+
+	var body []byte
+
+	if req.Body != nil {
+		var err error
+
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tflog.SubsystemInfo(req.Context(), logSubsystemAPI, "Dry run, not sending StatusCake API request", map[string]interface{}{
+		"http_method": req.Method,
+		"http_path":   req.URL.Path,
+		"http_body":   redactForm(string(body)),
+	})
+
+	return nil, errDryRun
+}
@@ -0,0 +1,101 @@
+package statuscake
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGuardWrites(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		t.Errorf("expected no requests to be sent, got %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	p := New("dev")()
+	meta := &providerMeta{readOnly: true}
+
+	for name, r := range p.ResourcesMap {
+		d := r.TestResourceData()
+		d.SetId("123")
+
+		operations := map[string]func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics{
+			"create": r.CreateContext,
+			"update": r.UpdateContext,
+			"delete": r.DeleteContext,
+		}
+
+		for operation, fn := range operations {
+			diags := fn(context.Background(), d, meta)
+
+			if !diags.HasError() || !strings.Contains(diags[0].Detail, operation) {
+				t.Errorf("expected %s of %s to be refused, got %v", operation, name, diags)
+			}
+		}
+	}
+}
+
+func TestCheckWritable(t *testing.T) {
+	t.Parallel()
+
+	if diags := checkWritable(&providerMeta{}, "create", "statuscake_uptime_test", ""); diags != nil {
+		t.Errorf("expected writes to be allowed, got %v", diags)
+	}
+
+	diags := checkWritable(&providerMeta{readOnly: true}, "delete", "statuscake_uptime_test", "123")
+
+	if want := "Refusing to delete statuscake_uptime_test 123"; !diags.HasError() || !strings.HasPrefix(diags[0].Detail, want) {
+		t.Errorf("expected %q, got %v", want, diags)
+	}
+}
+
+func TestDryRunTransport(t *testing.T) {
+	t.Parallel()
+
+	var methods []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+
+	ctx := withLogging(tflogtest.RootLogger(context.Background(), &output))
+	client := &http.Client{Transport: &dryRunTransport{next: http.DefaultTransport}}
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v1/uptime/123", nil)
+	res, err := client.Do(req)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	res.Body.Close()
+
+	req, _ = http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/v1/uptime", strings.NewReader("name=My+Site&check_rate=300&basic_pass=hunter2"))
+
+	if _, err := client.Do(req); !errors.Is(err, errDryRun) {
+		t.Errorf("expected a dry run error, got %v", err)
+	}
+
+	if len(methods) != 1 || methods[0] != http.MethodGet {
+		t.Errorf("expected only the GET to be sent, got %v", methods)
+	}
+
+	if got := output.String(); !strings.Contains(got, `"http_path":"/v1/uptime"`) || !strings.Contains(got, "check_rate=300") {
+		t.Errorf("expected the request to be logged, got %q", got)
+	}
+
+	assertNoSecrets(t, output.String())
+}